@@ -3,6 +3,9 @@ package main
 import (
 	"crossword-go/utils"
 	"fmt"
+	"math/rand"
+	"os"
+	"time"
 )
 
 func main() {
@@ -17,23 +20,24 @@ func main() {
 	}
 
 	// shuffle words
-	for i := range words {
-		j := utils.RandInt(i, len(words))
-		words[i], words[j] = words[j], words[i]
-	}
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	words = utils.ShuffleWords(words, rng)
 
 	// Create a new crossword puzzle (adjust dimensions as needed)
 	puzzle := utils.NewCrossword(15, 15)
 
-	// Generate the puzzle
-	success := puzzle.GeneratePuzzle(words)
+	// Generate the puzzle, skipping the words that don't fit
+	result := puzzle.GenerateWithOptions(words, utils.GenerateOptions{})
 
-	if success {
-		printCrossWordTerminal(puzzle)
+	if result.Success {
+		// Color the output only when writing to a terminal
+		info, err := os.Stdout.Stat()
+		color := err == nil && info.Mode()&os.ModeCharDevice != 0
+		fmt.Print(utils.RenderTerminal(puzzle, color))
 
 		// Render to PNG
 		config := utils.DefaultConfig()
-		err := utils.RenderPuzzleToPNG(puzzle, "crossword.png", config)
+		err = utils.RenderPuzzleToPNG(puzzle, "crossword.png", config)
 		if err != nil {
 			fmt.Printf("Error rendering puzzle: %v\n", err)
 			return
@@ -46,26 +50,3 @@ func main() {
 	}
 
 }
-
-func printCrossWordTerminal(puzzle *utils.Crossword) {
-	// Print the puzzle
-	board := puzzle.GetBoard()
-	for i := range board {
-		for j := range board[i] {
-			//fmt.Printf("%c ", board[i][j])
-			// always uppercase
-			fmt.Printf(string(board[i][j]) + " ")
-		}
-		fmt.Println()
-	}
-
-	// Print word placements
-	fmt.Println("\nWord Placements:")
-	for _, placement := range puzzle.GetPlacements() {
-		direction := "Across"
-		if placement.Dir == utils.Vertical {
-			direction = "Down"
-		}
-		fmt.Printf("%s: (%d,%d) %s\n", placement.Word, placement.X, placement.Y, direction)
-	}
-}
@@ -0,0 +1,152 @@
+package utils
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Enumeration returns the answer length shown after a clue, such as "(6)".
+// Every entry is a single word for now, so it holds a single number.
+func (c *Crossword) Enumeration(p WordPlacement) string {
+	return fmt.Sprintf("(%d)", p.Length)
+}
+
+// ClueLabel returns the printed label of a placement, such as "7A" for
+// 7 Across or "7D" for 7 Down
+func (c *Crossword) ClueLabel(p WordPlacement) string {
+	if p.Dir == Horizontal {
+		return fmt.Sprintf("%dA", p.Number)
+	}
+	return fmt.Sprintf("%dD", p.Number)
+}
+
+// PlacementByNumber returns the placement with the given clue number and
+// direction, false when there is none
+func (c *Crossword) PlacementByNumber(number int, dir Direction) (WordPlacement, bool) {
+	for _, p := range c.placements {
+		if p.Number == number && p.Dir == dir {
+			return p, true
+		}
+	}
+	return WordPlacement{}, false
+}
+
+// SetClues sets the clue text of every placement found in clues, keyed by
+// the placed word
+func (c *Crossword) SetClues(clues map[string]string) {
+	for i, p := range c.placements {
+		if clue, ok := clues[p.Word]; ok {
+			c.placements[i].Clue = clue
+		}
+	}
+}
+
+// ExportCluesMarkdown returns the clue list as a Markdown document with an
+// "## Across" and a "## Down" section, each entry numbered like
+// "1\. clue text (6)" in a paragraph of its own. The escaped dot keeps
+// Markdown from turning the entries into an ordered list, which would
+// renumber them from 1.
+func ExportCluesMarkdown(puzzle *Crossword) string {
+	placements := append([]WordPlacement(nil), puzzle.GetPlacements()...)
+	sort.SliceStable(placements, func(i, j int) bool {
+		return placements[i].Number < placements[j].Number
+	})
+
+	var b strings.Builder
+	for _, section := range []struct {
+		title string
+		dir   Direction
+	}{{"Across", Horizontal}, {"Down", Vertical}} {
+		fmt.Fprintf(&b, "## %s\n\n", section.title)
+		for _, p := range placements {
+			if p.Dir != section.dir {
+				continue
+			}
+			if p.Clue != "" {
+				fmt.Fprintf(&b, "%d\\. %s %s\n\n", p.Number, p.Clue, puzzle.Enumeration(p))
+			} else {
+				fmt.Fprintf(&b, "%d\\. %s\n\n", p.Number, puzzle.Enumeration(p))
+			}
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// Clue is a numbered clue as printed beside the grid
+type Clue struct {
+	Number int
+	Dir    Direction
+	Text   string
+}
+
+// Clues returns the clues of the placements in print order, across before
+// down and each by number
+func (c *Crossword) Clues() []Clue {
+	placements := append([]WordPlacement(nil), c.placements...)
+	sort.SliceStable(placements, func(i, j int) bool {
+		if placements[i].Dir != placements[j].Dir {
+			return placements[i].Dir == Horizontal
+		}
+		return placements[i].Number < placements[j].Number
+	})
+
+	clues := make([]Clue, len(placements))
+	for i, p := range placements {
+		clues[i] = Clue{Number: p.Number, Dir: p.Dir, Text: p.Clue}
+	}
+	return clues
+}
+
+// ShuffleClues returns a shuffled copy of the clues using the given source.
+// Each clue keeps its number, only the presentation order changes.
+func ShuffleClues(clues []Clue, rng *rand.Rand) []Clue {
+	shuffled := append([]Clue(nil), clues...)
+	rng.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	return shuffled
+}
+
+// clueReference matches references like "12A", "12-D", "12-Down" or
+// "3 across". A lone letter must be attached or hyphenated, so "1990 a
+// Roma" is no reference.
+var clueReference = regexp.MustCompile(`(?i)\b(\d+)(?:-?([ad])|[- ]?(across|down))\b`)
+
+// ValidateClueReferences scans the clue texts, keyed by number, for
+// references to other entries and returns, as labels like "12A", those
+// pointing at no entry of the grid. Clues are scanned in number order and
+// each dangling reference is reported once.
+func (c *Crossword) ValidateClueReferences(clues map[int]string) []string {
+	numbers := make([]int, 0, len(clues))
+	for number := range clues {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+
+	var dangling []string
+	seen := make(map[string]bool)
+	for _, number := range numbers {
+		for _, match := range clueReference.FindAllStringSubmatch(clues[number], -1) {
+			n, err := strconv.Atoi(match[1])
+			if err != nil {
+				continue
+			}
+
+			dir := Horizontal
+			if strings.HasPrefix(strings.ToLower(match[2]+match[3]), "d") {
+				dir = Vertical
+			}
+
+			label := c.ClueLabel(WordPlacement{Number: n, Dir: dir})
+			if _, ok := c.PlacementByNumber(n, dir); !ok && !seen[label] {
+				seen[label] = true
+				dangling = append(dangling, label)
+			}
+		}
+	}
+	return dangling
+}
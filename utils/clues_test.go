@@ -0,0 +1,189 @@
+package utils
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestEnumeration(t *testing.T) {
+	tests := []struct {
+		name string
+		p    WordPlacement
+		want string
+	}{
+		{"six letters", WordPlacement{Word: "CAVALL", Length: 6}, "(6)"},
+		{"three letters", WordPlacement{Word: "CAT", Length: 3}, "(3)"},
+		{"down word", WordPlacement{Word: "CANE", Length: 4, Dir: Vertical}, "(4)"},
+	}
+
+	c := NewCrossword(6, 6)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.Enumeration(tt.p); got != tt.want {
+				t.Errorf("Enumeration() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClueLabel(t *testing.T) {
+	tests := []struct {
+		name string
+		p    WordPlacement
+		want string
+	}{
+		{"across", WordPlacement{Word: "CASA", Length: 4, Number: 7, Dir: Horizontal}, "7A"},
+		{"down", WordPlacement{Word: "CANE", Length: 4, Number: 7, Dir: Vertical}, "7D"},
+		{"two digits", WordPlacement{Word: "LUPO", Length: 4, Number: 12, Dir: Vertical}, "12D"},
+	}
+
+	c := NewCrossword(6, 6)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.ClueLabel(tt.p); got != tt.want {
+				t.Errorf("ClueLabel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlacementByNumber(t *testing.T) {
+	c := crossingPuzzle()
+	c.putWord("SOLE", 1, 3, Vertical)
+	c.AssignNumbers()
+
+	tests := []struct {
+		name   string
+		number int
+		dir    Direction
+		word   string
+		ok     bool
+	}{
+		{"1 across", 1, Horizontal, "CASA", true},
+		{"1 down", 1, Vertical, "CANE", true},
+		{"2 down", 2, Vertical, "SOLE", true},
+		{"2 across", 2, Horizontal, "", false},
+		{"missing number", 99, Horizontal, "", false},
+		{"zero", 0, Horizontal, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, ok := c.PlacementByNumber(tt.number, tt.dir)
+			if ok != tt.ok || p.Word != tt.word {
+				t.Errorf("PlacementByNumber(%d, %v) = %q, %v, want %q, %v", tt.number, tt.dir, p.Word, ok, tt.word, tt.ok)
+			}
+			if ok && (p.Number != tt.number || p.Dir != tt.dir) {
+				t.Errorf("got %+v", p)
+			}
+		})
+	}
+}
+
+func TestExportCluesMarkdown(t *testing.T) {
+	withClues := crossingPuzzle()
+	withClues.putWord("SOLE", 1, 3, Vertical)
+	withClues.AssignNumbers()
+	withClues.SetClues(map[string]string{"CASA": "Abitazione", "CANE": "Abbaia", "SOLE": "Stella"})
+
+	tests := []struct {
+		name   string
+		puzzle *Crossword
+		want   string
+	}{
+		{"without clues", crossingPuzzle(), "" +
+			"## Across\n\n" +
+			"1\\. (4)\n\n" +
+			"## Down\n\n" +
+			"1\\. (4)\n"},
+		{"with clues", withClues, "" +
+			"## Across\n\n" +
+			"1\\. Abitazione (4)\n\n" +
+			"## Down\n\n" +
+			"1\\. Abbaia (4)\n\n" +
+			"2\\. Stella (4)\n"},
+		{"empty", NewCrossword(3, 3), "## Across\n\n## Down\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExportCluesMarkdown(tt.puzzle); got != tt.want {
+				t.Errorf("ExportCluesMarkdown() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShuffleClues(t *testing.T) {
+	clues := []Clue{
+		{Number: 1, Dir: Horizontal, Text: "Abitazione"},
+		{Number: 1, Dir: Vertical, Text: "Abbaia"},
+		{Number: 2, Dir: Vertical, Text: "Stella"},
+		{Number: 3, Dir: Horizontal, Text: "Ulula"},
+		{Number: 4, Dir: Horizontal, Text: "Roditore"},
+		{Number: 5, Dir: Vertical, Text: "Felino"},
+	}
+
+	tests := []struct {
+		name  string
+		clues []Clue
+		seed  int64
+	}{
+		{"empty", nil, 1},
+		{"one", clues[:1], 1},
+		{"all", clues, 1},
+		{"other seed", clues, 99},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := slices.Clone(tt.clues)
+			got := ShuffleClues(input, rand.New(rand.NewSource(tt.seed)))
+
+			if !slices.Equal(input, tt.clues) {
+				t.Errorf("input changed to %v", input)
+			}
+			if len(got) != len(tt.clues) {
+				t.Fatalf("got %d clues, want %d", len(got), len(tt.clues))
+			}
+			// Every clue is there once, its number still beside its text
+			for _, clue := range tt.clues {
+				if n := len(slices.DeleteFunc(slices.Clone(got), func(c Clue) bool { return c != clue })); n != 1 {
+					t.Errorf("clue %+v appears %d times", clue, n)
+				}
+			}
+			if again := ShuffleClues(input, rand.New(rand.NewSource(tt.seed))); !slices.Equal(again, got) {
+				t.Errorf("same seed gave %v, then %v", got, again)
+			}
+		})
+	}
+}
+
+func TestValidateClueReferences(t *testing.T) {
+	tests := []struct {
+		name  string
+		clues map[int]string
+		want  []string
+	}{
+		{"attached letters", map[int]string{1: "Vedi 1A e 1d"}, nil},
+		{"hyphenated", map[int]string{1: "Vedi 1-A, 1-D e 1-Down"}, nil},
+		{"full words", map[int]string{1: "See 1 across and 1 DOWN"}, nil},
+		{"dangling", map[int]string{1: "Vedi 2A, 3-D e 4 across"}, []string{"2A", "3D", "4A"}},
+		{"year before a word", map[int]string{1: "Nato nel 1990 a Roma"}, nil},
+		{"spaced letter", map[int]string{1: "Vale 12 d di sconto"}, nil},
+		{"inside a word", map[int]string{1: "Modello X12A3"}, nil},
+		{"reported once", map[int]string{1: "Vedi 5D", 2: "Come 5-D"}, []string{"5D"}},
+		{"number order", map[int]string{2: "Vedi 6A", 1: "Vedi 7A"}, []string{"7A", "6A"}},
+		{"no clues", nil, nil},
+	}
+
+	c := crossingPuzzle()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.ValidateClueReferences(tt.clues); !slices.Equal(got, tt.want) {
+				t.Errorf("ValidateClueReferences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
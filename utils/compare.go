@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"unicode/utf8"
+)
+
+// Equal reports whether two crosswords have the same dimensions, board
+// contents, rebus cells and placements, regardless of placement order
+func (c *Crossword) Equal(other *Crossword) bool {
+	if other == nil || c.width != other.width || c.height != other.height {
+		return false
+	}
+
+	for x := range c.board {
+		for y := range c.board[x] {
+			if c.board[x][y] != other.board[x][y] {
+				return false
+			}
+		}
+	}
+	if !maps.Equal(c.rebus, other.rebus) {
+		return false
+	}
+
+	if len(c.placements) != len(other.placements) {
+		return false
+	}
+	counts := make(map[WordPlacement]int, len(c.placements))
+	for _, p := range c.placements {
+		counts[p]++
+	}
+	for _, p := range other.placements {
+		if counts[p] == 0 {
+			return false
+		}
+		counts[p]--
+	}
+
+	return true
+}
+
+// rebusMark flags the letter count written for a rebus cell in Hash. It
+// lies above every rune, so a rebus cell can't hash like plain letters.
+const rebusMark = 1 << 31
+
+// Hash returns a stable FNV-1a hash of the dimensions and board contents,
+// including every letter of rebus cells, so unchanged grids hash equal and
+// any cell edit changes the value
+func (c *Crossword) Hash() uint64 {
+	h := fnv.New64a()
+
+	var buf [4]byte
+	write := func(v uint32) {
+		binary.LittleEndian.PutUint32(buf[:], v)
+		h.Write(buf[:])
+	}
+
+	write(uint32(c.width))
+	write(uint32(c.height))
+	for x := range c.board {
+		for y, cell := range c.board[x] {
+			letters, ok := c.rebus[[2]int{x, y}]
+			if !ok {
+				write(uint32(cell))
+				continue
+			}
+
+			write(rebusMark | uint32(utf8.RuneCountInString(letters)))
+			for _, r := range letters {
+				write(uint32(r))
+			}
+		}
+	}
+
+	return h.Sum64()
+}
+
+// DiffGrids returns, in reading order, the cells whose content differs
+// between two puzzles of the same dimensions
+func DiffGrids(a, b *Crossword) ([][2]int, error) {
+	if a.width != b.width || a.height != b.height {
+		return nil, fmt.Errorf("grids are %dx%d and %dx%d", a.width, a.height, b.width, b.height)
+	}
+
+	var cells [][2]int
+	for x := range a.board {
+		for y := range a.board[x] {
+			if a.cellText(x, y) != b.cellText(x, y) {
+				cells = append(cells, [2]int{x, y})
+			}
+		}
+	}
+	return cells, nil
+}
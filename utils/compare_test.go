@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(c *Crossword)
+		equal  bool
+	}{
+		{"clone", func(c *Crossword) {}, true},
+		{"reordered placements", func(c *Crossword) {
+			c.placements[0], c.placements[1] = c.placements[1], c.placements[0]
+		}, true},
+		{"cell changed", func(c *Crossword) { c.board[4][4] = 'X' }, false},
+		{"word removed", func(c *Crossword) { c.removeWord("CASA", 1, 1, Horizontal) }, false},
+		{"clue changed", func(c *Crossword) { c.placements[0].Clue = "Abitazione" }, false},
+		{"rebus cell added", func(c *Crossword) { c.rebus = map[[2]int]string{{1, 1}: "CA"} }, false},
+		{"empty rebus map", func(c *Crossword) { c.rebus = map[[2]int]string{} }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := crossingPuzzle()
+			clone := original.Clone()
+			tt.mutate(clone)
+
+			if got := original.Equal(clone); got != tt.equal {
+				t.Errorf("Equal() = %v, want %v", got, tt.equal)
+			}
+		})
+	}
+
+	// Rebus cells sharing a first letter, which Hash and DiffGrids tell apart
+	rebus := []struct {
+		name  string
+		a, b  string
+		equal bool
+	}{
+		{"same rebus", "3x1:(MA)..", "3x1:(MA)..", true},
+		{"other rebus letters", "3x1:(MA)..", "3x1:(MX)..", false},
+		{"rebus and plain letter", "3x1:(MA)..", "3x1:M..", false},
+	}
+	for _, tt := range rebus {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := Decode(tt.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := Decode(tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			diff, _ := DiffGrids(a, b)
+			if got := a.Equal(b); got != tt.equal || got != (a.Hash() == b.Hash()) || got != (len(diff) == 0) {
+				t.Errorf("Equal() = %v, want %v; hashes equal %v, diff %v", got, tt.equal, a.Hash() == b.Hash(), diff)
+			}
+		})
+	}
+
+	if crossingPuzzle().Equal(NewCrossword(6, 7)) {
+		t.Error("grids of different sizes are equal")
+	}
+	if crossingPuzzle().Equal(nil) {
+		t.Error("grid equals nil")
+	}
+}
+
+func TestHash(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(c *Crossword)
+		equal  bool
+	}{
+		{"clone", func(c *Crossword) {}, true},
+		{"reordered placements", func(c *Crossword) {
+			c.placements[0], c.placements[1] = c.placements[1], c.placements[0]
+		}, true},
+		{"letter changed", func(c *Crossword) { c.board[1][3] = 'X' }, false},
+		{"empty cell filled", func(c *Crossword) { c.board[4][4] = 'X' }, false},
+		{"block added", func(c *Crossword) { c.board[4][4] = '*' }, false},
+		{"word removed", func(c *Crossword) { c.removeWord("CASA", 1, 1, Horizontal) }, false},
+		{"word added", func(c *Crossword) { c.putWord("SOLE", 1, 3, Vertical) }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := crossingPuzzle()
+			clone := original.Clone()
+			tt.mutate(clone)
+
+			if got := original.Hash() == clone.Hash(); got != tt.equal {
+				t.Errorf("hashes equal = %v, want %v", got, tt.equal)
+			}
+		})
+	}
+
+	// Same cells on a differently shaped board
+	wide, tall := NewCrossword(6, 4), NewCrossword(4, 6)
+	if wide.Hash() == tall.Hash() {
+		t.Error("6x4 and 4x6 empty grids hash equal")
+	}
+}
+
+func TestDiffGrids(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(c *Crossword)
+		want   [][2]int
+	}{
+		{"clone", func(c *Crossword) {}, nil},
+		{"letter changed", func(c *Crossword) { c.board[1][3] = 'X' }, [][2]int{{1, 3}}},
+		{"two cells changed", func(c *Crossword) {
+			c.board[4][4] = '*'
+			c.board[2][1] = 'O'
+		}, [][2]int{{2, 1}, {4, 4}}},
+		{"word added", func(c *Crossword) { c.putWord("SOLE", 1, 3, Vertical) }, [][2]int{{0, 3}, {2, 3}, {3, 3}, {4, 3}, {5, 3}}},
+		{"rebus cell", func(c *Crossword) { c.rebus = map[[2]int]string{{1, 1}: "CA"} }, [][2]int{{1, 1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := crossingPuzzle()
+			clone := original.Clone()
+			tt.mutate(clone)
+
+			got, err := DiffGrids(original, clone)
+			if err != nil {
+				t.Fatalf("DiffGrids() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("DiffGrids() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got, err := DiffGrids(crossingPuzzle(), NewCrossword(6, 7)); err == nil {
+		t.Errorf("DiffGrids() of 6x6 and 6x7 grids = %v, want an error", got)
+	}
+}
@@ -0,0 +1,80 @@
+package utils
+
+import "fmt"
+
+// SetBlock marks the cell at the given position as a block
+func (c *Crossword) SetBlock(x, y int) error {
+	if err := c.checkEditable(x, y); err != nil {
+		return err
+	}
+
+	c.board[x][y] = '*'
+	c.frontier = nil
+	return nil
+}
+
+// ClearCell empties the cell at the given position
+func (c *Crossword) ClearCell(x, y int) error {
+	if err := c.checkEditable(x, y); err != nil {
+		return err
+	}
+	if c.isTemplateBlock(x, y) {
+		return fmt.Errorf("cell (%d,%d) is a template block", x, y)
+	}
+
+	c.board[x][y] = ' '
+	c.frontier = nil
+	return nil
+}
+
+// checkEditable refuses edits outside the board or on letters of a placed word
+func (c *Crossword) checkEditable(x, y int) error {
+	if !c.isValidPosition(x, y) {
+		return fmt.Errorf("cell (%d,%d) is outside the board", x, y)
+	}
+	if c.hWords[x][y] > 0 || c.vWords[x][y] > 0 {
+		return fmt.Errorf("cell (%d,%d) belongs to a placed word", x, y)
+	}
+	return nil
+}
+
+// FinalizeBlocks recomputes the blocks from the entries on the board: a
+// block stays just before and after every entry, is mirrored through the
+// center when that cell holds no letter, and every other non-letter cell is
+// emptied. Template blocks are kept.
+func (c *Crossword) FinalizeBlocks() {
+	required := make(map[[2]int]bool)
+	for _, e := range c.Entries() {
+		for _, i := range []int{-1, e.Length} {
+			if x, y := e.cell(i); c.isValidPosition(x, y) {
+				required[[2]int{x, y}] = true
+			}
+		}
+	}
+
+	// Mirror for rotational symmetry where the board allows it
+	var mirrored [][2]int
+	for cell := range required {
+		x, y := c.height-1-cell[0], c.width-1-cell[1]
+		if c.isValidPosition(x, y) && !isLetter(c.board[x][y]) {
+			mirrored = append(mirrored, [2]int{x, y})
+		}
+	}
+	for _, cell := range mirrored {
+		required[cell] = true
+	}
+
+	for x := range c.board {
+		for y, cell := range c.board[x] {
+			if isLetter(cell) {
+				continue
+			}
+			if required[[2]int{x, y}] || c.isTemplateBlock(x, y) {
+				c.board[x][y] = '*'
+			} else {
+				c.board[x][y] = ' '
+			}
+		}
+	}
+	c.frontier = nil
+}
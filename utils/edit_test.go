@@ -0,0 +1,137 @@
+package utils
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSetBlock(t *testing.T) {
+	tests := []struct {
+		name    string
+		x, y    int
+		wantErr bool
+	}{
+		{"empty cell", 4, 4, false},
+		{"end block", 1, 0, false},
+		{"across letter", 1, 2, true},
+		{"shared letter", 1, 1, true},
+		{"outside", 6, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := crossingPuzzle()
+			before := c.Clone()
+
+			err := c.SetBlock(tt.x, tt.y)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetBlock(%d, %d) error = %v, wantErr %v", tt.x, tt.y, err, tt.wantErr)
+			}
+			if err == nil && c.GetBoard()[tt.x][tt.y] != '*' {
+				t.Errorf("cell (%d,%d) = %q, want a block", tt.x, tt.y, c.GetBoard()[tt.x][tt.y])
+			}
+			if err != nil && !c.Equal(before) {
+				t.Error("refused edit changed the puzzle")
+			}
+		})
+	}
+}
+
+func TestClearCell(t *testing.T) {
+	tests := []struct {
+		name    string
+		x, y    int
+		wantErr bool
+	}{
+		{"end block", 1, 0, false},
+		{"empty cell", 4, 4, false},
+		{"down letter", 3, 1, true},
+		{"outside", 0, -1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := crossingPuzzle()
+
+			err := c.ClearCell(tt.x, tt.y)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ClearCell(%d, %d) error = %v, wantErr %v", tt.x, tt.y, err, tt.wantErr)
+			}
+			if err == nil && c.GetBoard()[tt.x][tt.y] != ' ' {
+				t.Errorf("cell (%d,%d) = %q, want empty", tt.x, tt.y, c.GetBoard()[tt.x][tt.y])
+			}
+		})
+	}
+}
+
+func TestFinalizeBlocks(t *testing.T) {
+	generated := func(seed int64) func() *Crossword {
+		return func() *Crossword {
+			c := NewCrossword(13, 11)
+			c.GenerateWithOptions(testWords, GenerateOptions{Rand: rand.New(rand.NewSource(seed)).Intn})
+			return c
+		}
+	}
+	scattered := func() *Crossword {
+		c := crossingPuzzle()
+		c.board[5][5] = '*'
+		c.board[3][4] = '*'
+		return c
+	}
+
+	tests := []struct {
+		name   string
+		puzzle func() *Crossword
+	}{
+		{"crossing", crossingPuzzle},
+		{"scattered blocks", scattered},
+		{"seed 1", generated(1)},
+		{"seed 2", generated(2)},
+		{"seed 3", generated(3)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.puzzle()
+			entries := c.EntryWords()
+			c.FinalizeBlocks()
+
+			if got := c.EntryWords(); !slices.Equal(got, entries) {
+				t.Errorf("entries changed from %v to %v", entries, got)
+			}
+			if !c.PlacementsConsistent() {
+				t.Error("placements no longer match the board")
+			}
+
+			// A block closes each end of every entry, so collinear
+			// neighbors one cell apart share exactly one block
+			boundary := make(map[[2]int]bool)
+			for _, e := range c.Entries() {
+				for _, i := range []int{-1, e.Length} {
+					x, y := e.cell(i)
+					if !c.isValidPosition(x, y) {
+						continue
+					}
+					boundary[[2]int{x, y}] = true
+					if c.board[x][y] != '*' {
+						t.Errorf("entry %s ends next to %q at (%d,%d)", e.Word, c.board[x][y], x, y)
+					}
+				}
+			}
+
+			// Every other block mirrors a boundary block
+			for x := range c.board {
+				for y, cell := range c.board[x] {
+					mirror := [2]int{c.height - 1 - x, c.width - 1 - y}
+					if cell == '*' && !boundary[[2]int{x, y}] && !boundary[mirror] {
+						t.Errorf("stray block at (%d,%d)", x, y)
+					}
+					if boundary[[2]int{x, y}] && !isLetter(c.board[mirror[0]][mirror[1]]) && c.board[mirror[0]][mirror[1]] != '*' {
+						t.Errorf("block at (%d,%d) is not mirrored at %v", x, y, mirror)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Encode serializes the board into a compact, URL-friendly string of the
+// form "WxH:cells". Cells are listed row by row: letters as themselves,
+// rebus cells as their letters in parentheses, blocks as '.' and runs of
+// empty cells as their decimal count. Letters that are digits or one of
+// ".()~" are preceded by a '~'. Placement metadata is not encoded.
+func (c *Crossword) Encode() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%dx%d:", c.width, c.height)
+
+	empty := 0
+	for x := 0; x < c.height; x++ {
+		for y := 0; y < c.width; y++ {
+			cell := c.board[x][y]
+			if cell == ' ' {
+				empty++
+				continue
+			}
+
+			if empty > 0 {
+				sb.WriteString(strconv.Itoa(empty))
+				empty = 0
+			}
+			if letters, ok := c.rebus[[2]int{x, y}]; ok {
+				sb.WriteByte('(')
+				for _, r := range letters {
+					writeEscaped(&sb, r)
+				}
+				sb.WriteByte(')')
+			} else if cell == '*' {
+				sb.WriteRune('.')
+			} else {
+				writeEscaped(&sb, cell)
+			}
+		}
+	}
+	if empty > 0 {
+		sb.WriteString(strconv.Itoa(empty))
+	}
+
+	return sb.String()
+}
+
+// escapeMark precedes letters Encode would otherwise read as syntax
+const escapeMark = '~'
+
+// writeEscaped writes a letter, escaping it when it is a digit or one of
+// the characters with a meaning in encoded data
+func writeEscaped(sb *strings.Builder, r rune) {
+	if unicode.IsDigit(r) || strings.ContainsRune(".()~", r) {
+		sb.WriteRune(escapeMark)
+	}
+	sb.WriteRune(r)
+}
+
+// maxDecodedCells bounds the size of the grids Decode accepts, so a
+// crafted header can't make it allocate an arbitrary amount of memory
+const maxDecodedCells = 1 << 20
+
+// Decode rebuilds a crossword board from a string produced by Encode
+func Decode(s string) (*Crossword, error) {
+	header, data, found := strings.Cut(s, ":")
+	if !found {
+		return nil, fmt.Errorf("missing ':' separator in %q", s)
+	}
+
+	var width, height int
+	if _, err := fmt.Sscanf(header, "%dx%d", &width, &height); err != nil {
+		return nil, fmt.Errorf("invalid dimensions %q: %v", header, err)
+	}
+	if width <= 0 || height <= 0 || width > maxDecodedCells/height {
+		return nil, fmt.Errorf("invalid dimensions %dx%d", width, height)
+	}
+	total := width * height
+
+	// Grow the cells from the data, the header alone allocates nothing
+	var cells []rune
+	rebus := make(map[int]string)
+	runes := []rune(data)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case unicode.IsDigit(r):
+			// Run of empty cells
+			j := i
+			for j < len(runes) && unicode.IsDigit(runes[j]) {
+				j++
+			}
+			count, err := strconv.Atoi(string(runes[i:j]))
+			if err != nil {
+				return nil, err
+			}
+			if count > total-len(cells) {
+				return nil, fmt.Errorf("encoded data exceeds %dx%d cells", width, height)
+			}
+			for k := 0; k < count; k++ {
+				cells = append(cells, ' ')
+			}
+			i = j - 1
+		case len(cells) == total:
+			return nil, fmt.Errorf("encoded data exceeds %dx%d cells", width, height)
+		case r == '.':
+			cells = append(cells, '*')
+		case r == escapeMark:
+			if i+1 == len(runes) {
+				return nil, fmt.Errorf("unterminated escape at offset %d", i)
+			}
+			i++
+			cells = append(cells, runes[i])
+		case r == '(':
+			// Rebus cell, its letters run up to the closing parenthesis
+			var letters []rune
+			j := i + 1
+			for ; j < len(runes) && runes[j] != ')'; j++ {
+				if runes[j] == escapeMark && j+1 < len(runes) {
+					j++
+				}
+				letters = append(letters, runes[j])
+			}
+			if j == len(runes) {
+				return nil, fmt.Errorf("unterminated rebus cell at offset %d", i)
+			}
+			if len(letters) < 2 {
+				return nil, fmt.Errorf("rebus cell at offset %d has fewer than two letters", i)
+			}
+			rebus[len(cells)] = string(letters)
+			cells = append(cells, letters[0])
+			i = j
+		default:
+			cells = append(cells, r)
+		}
+	}
+
+	if len(cells) != total {
+		return nil, fmt.Errorf("encoded data has %d cells, expected %d", len(cells), total)
+	}
+
+	c := NewCrossword(width, height)
+	for x := 0; x < height; x++ {
+		copy(c.board[x], cells[x*width:(x+1)*width])
+	}
+	for i, letters := range rebus {
+		if c.rebus == nil {
+			c.rebus = make(map[[2]int]string, len(rebus))
+		}
+		c.rebus[[2]int{i / width, i % width}] = letters
+	}
+
+	return c, nil
+}
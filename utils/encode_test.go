@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"maps"
+	"math/rand"
+	"testing"
+)
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	generated := NewCrossword(12, 9)
+	rng := rand.New(rand.NewSource(1))
+	generated.GenerateWithOptions([]string{"GATTO", "TOPO", "ORSO", "CANE", "PAPPAGALLO", "ELEFANTE"},
+		GenerateOptions{Rand: rng.Intn})
+
+	accented, err := CrosswordFromGrid([]string{"CITTÀ#", "  #  ."})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// rows builds a board cell by cell, letters the grid parser would reject included
+	rows := func(lines ...string) *Crossword {
+		c := NewCrossword(len([]rune(lines[0])), len(lines))
+		for x, line := range lines {
+			c.board[x] = []rune(line)
+		}
+		return c
+	}
+	rebus := rows("M  ", "*1 ")
+	rebus.rebus = map[[2]int]string{{0, 0}: "M)1~", {1, 1}: "12"}
+
+	tests := []struct {
+		name string
+		c    *Crossword
+	}{
+		{"empty", NewCrossword(4, 3)},
+		{"crossing", crossingPuzzle()},
+		{"generated", generated},
+		{"accented", accented},
+		{"digit letter", rows("A1B", "   ")},
+		{"digits before empty runs", rows("12 ", " 3*")},
+		{"syntax letters", rows(".()", "~* ")},
+		{"other digits", rows("A٣ ", "   ")},
+		{"escaped rebus", rebus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := tt.c.Encode()
+			decoded, err := Decode(encoded)
+			if err != nil {
+				t.Fatalf("Decode(%q) error = %v", encoded, err)
+			}
+
+			if decoded.width != tt.c.width || decoded.height != tt.c.height {
+				t.Fatalf("decoded %dx%d, want %dx%d", decoded.width, decoded.height, tt.c.width, tt.c.height)
+			}
+			for x := range tt.c.board {
+				if got, want := string(decoded.board[x]), string(tt.c.board[x]); got != want {
+					t.Errorf("row %d = %q, want %q", x, got, want)
+				}
+			}
+			if !maps.Equal(decoded.rebus, tt.c.rebus) {
+				t.Errorf("rebus cells = %v, want %v", decoded.rebus, tt.c.rebus)
+			}
+		})
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+	}{
+		{"no separator", "3x3"},
+		{"bad header", "3by3:9"},
+		{"zero width", "0x3:"},
+		{"huge header", "200000x200000:"},
+		{"overflowing header", "4611686018427387904x4:"},
+		{"too few cells", "3x3:8"},
+		{"too many empty cells", "3x3:10"},
+		{"too many letters", "2x1:ABC"},
+		{"huge run", "1000x1000:40000000000"},
+		{"unterminated rebus", "2x1:A(BC"},
+		{"one letter rebus", "2x1:A(B)"},
+		{"rebus past the end", "2x1:AB(CD)"},
+		{"unterminated escape", "2x1:A~"},
+		{"unterminated escaped rebus", "2x1:A(B~)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if c, err := Decode(tt.s); err == nil {
+				t.Errorf("Decode(%q) = %dx%d grid, want an error", tt.s, c.width, c.height)
+			}
+		})
+	}
+}
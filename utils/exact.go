@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// exactAttempts is how many word orders GenerateExactly tries at most
+const exactAttempts = 50
+
+// GenerateExactly builds a puzzle of the given size holding exactly target
+// of the words. It tries several word orders within the timeout, each
+// crossing every word with one already placed where it can, and keeps the
+// connected grid with the most crossings, falling back to any grid of the
+// right size. The result fails when no attempt placed target words.
+func GenerateExactly(words []string, width, height, target int, timeout time.Duration) (*Crossword, GenerateResult) {
+	if target <= 0 || target > len(words) {
+		return NewCrossword(width, height), GenerateResult{
+			Unplaced: append([]string(nil), words...),
+			Reason:   fmt.Sprintf("cannot place %d of %d words", target, len(words)),
+		}
+	}
+
+	deadline := time.Now().Add(timeout)
+	order := append([]string(nil), words...)
+
+	var best *Crossword
+	var bestResult GenerateResult
+	var bestReport PuzzleReport
+	for attempt := 0; attempt < exactAttempts; attempt++ {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			break
+		}
+
+		// The first attempt keeps the caller's order
+		if attempt > 0 {
+			rand.Shuffle(len(order), func(i, j int) {
+				order[i], order[j] = order[j], order[i]
+			})
+		}
+
+		// Crossing every word keeps the grid connected, skipping the words
+		// that stand apart; only a scan of the whole grid can place those
+		c := NewCrossword(width, height)
+		result := c.GenerateWithOptions(order, GenerateOptions{MaxWords: target, FastScan: true, Timeout: remaining})
+		if !result.Success || result.Placed != target {
+			// A zero timeout would mean the default, not an expired budget
+			if remaining = time.Until(deadline); remaining <= 0 {
+				break
+			}
+			c = NewCrossword(width, height)
+			result = c.GenerateWithOptions(order, GenerateOptions{MaxWords: target, Timeout: remaining})
+			if !result.Success || result.Placed != target {
+				continue
+			}
+		}
+
+		// Interlocked grids beat scattered ones
+		report := c.Report()
+		if best == nil || report.Connected && !bestReport.Connected ||
+			report.Connected == bestReport.Connected && report.Intersections > bestReport.Intersections {
+			best, bestResult, bestReport = c, result, report
+		}
+	}
+
+	if best == nil {
+		return NewCrossword(width, height), GenerateResult{
+			Unplaced: append([]string(nil), words...),
+			Reason:   fmt.Sprintf("could not place exactly %d words", target),
+		}
+	}
+	return best, bestResult
+}
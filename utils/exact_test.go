@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGenerateExactly(t *testing.T) {
+	tests := []struct {
+		name          string
+		words         []string
+		width, height int
+		target        int
+		success       bool
+	}{
+		{"few of many", testWords, 12, 12, 5, true},
+		{"all", testWords[:4], 10, 10, 4, true},
+		{"one", testWords, 8, 8, 1, true},
+		{"no target", testWords, 10, 10, 0, false},
+		{"more than given", testWords[:3], 10, 10, 4, false},
+		{"too long to fit", []string{"PAPPAGALLO", "ELEFANTE"}, 5, 5, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, result := GenerateExactly(tt.words, tt.width, tt.height, tt.target, 2*time.Second)
+
+			if result.Success != tt.success {
+				t.Fatalf("Success = %v, want %v (reason %q)", result.Success, tt.success, result.Reason)
+			}
+			if c == nil || c.width != tt.width || c.height != tt.height {
+				t.Fatalf("no %dx%d puzzle returned", tt.width, tt.height)
+			}
+			if !tt.success {
+				if len(c.GetPlacements()) != 0 {
+					t.Errorf("failed puzzle holds %d words", len(c.GetPlacements()))
+				}
+				return
+			}
+
+			if got := len(c.GetPlacements()); got != tt.target || result.Placed != tt.target {
+				t.Errorf("placed %d words (result %d), want %d", got, result.Placed, tt.target)
+			}
+			given := make(map[string]bool, len(tt.words))
+			for _, word := range tt.words {
+				given[word] = true
+			}
+			for _, p := range c.GetPlacements() {
+				if !given[p.Word] {
+					t.Errorf("placed %q, which was not given", p.Word)
+				}
+			}
+			if tt.target > 1 && !c.Report().Connected {
+				t.Error("words do not interlock")
+			}
+		})
+	}
+}
+
+func TestGenerateExactlyTimeout(t *testing.T) {
+	words := dataWords(t)[:1000]
+
+	// Every word can't fit, so each attempt runs until the budget is spent
+	tests := []struct {
+		name    string
+		size    int
+		timeout time.Duration
+	}{
+		{"short budget", 60, 50 * time.Millisecond},
+		{"tiny budget", 60, 5 * time.Millisecond},
+		{"small grid", 40, 50 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			_, result := GenerateExactly(words, tt.size, tt.size, len(words), tt.timeout)
+			if elapsed := time.Since(start); elapsed > tt.timeout+100*time.Millisecond {
+				t.Errorf("took %v with a %v timeout", elapsed, tt.timeout)
+			}
+			if result.Success {
+				t.Errorf("placed all %d words", len(words))
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package utils
+
+import "time"
+
+// fitAttempts is how many random arrangements are tried per grid size
+const fitAttempts = 5
+
+// FitSmallestGrid tries square grids from minSide up to maxSide and returns
+// the first one holding every word, with its side length. The timeout
+// covers the whole search; false is returned when no size fits in time.
+func FitSmallestGrid(words []string, minSide, maxSide int, timeout time.Duration) (*Crossword, int, bool) {
+	deadline := time.Now().Add(timeout)
+
+	for side := max(minSide, 1); side <= maxSide; side++ {
+		for attempt := 0; attempt < fitAttempts; attempt++ {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return nil, 0, false
+			}
+
+			c := NewCrossword(side, side)
+			result := c.GenerateWithOptions(words, GenerateOptions{RequireAll: true, Timeout: remaining})
+			if result.Success {
+				return c, side, true
+			}
+		}
+	}
+
+	return nil, 0, false
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFitSmallestGrid(t *testing.T) {
+	tests := []struct {
+		name             string
+		words            []string
+		minSide, maxSide int
+		ok               bool
+	}{
+		{"pair", []string{"CASA", "CANE"}, 2, 20, true},
+		{"few animals", []string{"GATTO", "TOPO", "ORSO", "CANE", "LUPO"}, 3, 20, true},
+		{"animals", testWords, 5, 25, true},
+		{"range too small", []string{"PAPPAGALLO", "CANE"}, 3, 6, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, side, ok := FitSmallestGrid(tt.words, tt.minSide, tt.maxSide, 5*time.Second)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				if c != nil || side != 0 {
+					t.Errorf("failed with a %d grid", side)
+				}
+				return
+			}
+
+			if side < tt.minSide || side >= tt.maxSide {
+				t.Errorf("side %d, want smaller than the oversized %d", side, tt.maxSide)
+			}
+			if c.width != side || c.height != side {
+				t.Errorf("grid is %dx%d, want %dx%d", c.width, c.height, side, side)
+			}
+			if len(c.GetPlacements()) != len(tt.words) {
+				t.Errorf("placed %d of %d words", len(c.GetPlacements()), len(tt.words))
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package utils
+
+// frontierIndex returns the empty cells next to a letter, where a word
+// crossing that letter has to continue. The index is built on first use,
+// kept up to date by putWord and removeWord, and dropped by other edits.
+func (c *Crossword) frontierIndex() map[[2]int]bool {
+	if c.frontier == nil {
+		c.frontier = make(map[[2]int]bool)
+		for x := range c.board {
+			for y := range c.board[x] {
+				c.refreshFrontier(x, y)
+			}
+		}
+	}
+	return c.frontier
+}
+
+// updateFrontier refreshes the frontier around a placement just written
+// or removed, its end blocks included
+func (c *Crossword) updateFrontier(p WordPlacement) {
+	if c.frontier == nil {
+		return
+	}
+
+	directions := [][2]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}}
+	for i := -1; i <= p.Length; i++ {
+		x, y := p.cell(i)
+		c.refreshFrontier(x, y)
+		for _, d := range directions {
+			c.refreshFrontier(x+d[0], y+d[1])
+		}
+	}
+}
+
+// refreshFrontier recomputes whether a single cell belongs to the frontier
+func (c *Crossword) refreshFrontier(x, y int) {
+	if !c.isValidPosition(x, y) {
+		return
+	}
+
+	if c.board[x][y] == ' ' && c.hasAdjacentWords(x, y) {
+		c.frontier[[2]int{x, y}] = true
+	} else {
+		delete(c.frontier, [2]int{x, y})
+	}
+}
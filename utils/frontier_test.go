@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"fmt"
+	"maps"
+	"math/rand"
+	"testing"
+)
+
+// bruteFrontier recomputes the frontier from the board: the empty cells
+// with a letter next to them
+func bruteFrontier(c *Crossword) map[[2]int]bool {
+	frontier := make(map[[2]int]bool)
+	for x := range c.board {
+		for y, cell := range c.board[x] {
+			if cell != ' ' || !c.isValidPosition(x, y) {
+				continue
+			}
+			for _, d := range [][2]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}} {
+				if n := [2]int{x + d[0], y + d[1]}; c.isValidPosition(n[0], n[1]) && isLetter(c.board[n[0]][n[1]]) {
+					frontier[[2]int{x, y}] = true
+				}
+			}
+		}
+	}
+	return frontier
+}
+
+func TestFrontierMatchesBruteForce(t *testing.T) {
+	type step struct {
+		remove bool
+		word   string
+		x, y   int
+		dir    Direction
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{"place", []step{
+			{false, "SOLE", 1, 3, Vertical},
+			{false, "NOLO", 3, 1, Horizontal},
+		}},
+		{"place and remove", []step{
+			{false, "SOLE", 1, 3, Vertical},
+			{true, "SOLE", 1, 3, Vertical},
+			{false, "ESSE", 4, 1, Horizontal},
+			{true, "CASA", 1, 1, Horizontal},
+		}},
+		{"remove crossed words", []step{
+			{true, "CANE", 1, 1, Vertical},
+			{true, "CASA", 1, 1, Horizontal},
+			{false, "CANE", 1, 1, Vertical},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := crossingPuzzle()
+			c.frontierIndex()
+
+			for i, s := range tt.steps {
+				if s.remove {
+					c.removeWord(s.word, s.x, s.y, s.dir)
+				} else {
+					c.putWord(s.word, s.x, s.y, s.dir)
+				}
+				if got, want := c.frontier, bruteFrontier(c); !maps.Equal(got, want) {
+					t.Fatalf("after step %d frontier = %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFrontierAfterBacktracking(t *testing.T) {
+	for seed := int64(1); seed <= 5; seed++ {
+		t.Run(fmt.Sprintf("seed %d", seed), func(t *testing.T) {
+			rng := rand.New(rand.NewSource(seed))
+			c := NewCrossword(12, 12)
+			c.opts = GenerateOptions{Rand: rng.Intn, FastScan: true}
+			c.frontierIndex()
+
+			// Place words as the search does, undoing the latest one now
+			// and then the way backtracking would
+			var placed []WordPlacement
+			for i, word := range testWords {
+				if len(placed) > 0 && rng.Intn(3) == 0 {
+					last := placed[len(placed)-1]
+					placed = placed[:len(placed)-1]
+					c.removeWord(last.Word, last.X, last.Y, last.Dir)
+				} else if pos, ok := c.findBestPosition(word); ok {
+					c.putWord(word, pos.X, pos.Y, pos.Dir)
+					placed = append(placed, WordPlacement{X: pos.X, Y: pos.Y, Dir: pos.Dir, Word: word})
+				}
+
+				if c.frontier == nil {
+					t.Fatalf("step %d dropped the frontier", i)
+				}
+				if got, want := c.frontier, bruteFrontier(c); !maps.Equal(got, want) {
+					t.Fatalf("after step %d frontier = %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
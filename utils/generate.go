@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"fmt"
 	"math/rand"
+	"slices"
 	"time"
 )
 
@@ -25,6 +27,8 @@ type WordPlacement struct {
 	Dir    Direction
 	Length int
 	Word   string
+	Number int    // Clue number, set by AssignNumbers
+	Clue   string // Clue text, set by SetClues
 }
 
 // Crossword represents the crossword puzzle
@@ -36,6 +40,23 @@ type Crossword struct {
 	height     int
 	usedWords  map[string]bool
 	placements []WordPlacement
+	unplaced   []string          // words skipped during the last generation
+	opts       GenerateOptions   // options of the current generation
+	template   [][]bool          // permanent block cells, nil when unset
+	mask       [][]bool          // usable cells, nil when the whole board is usable
+	meta       Meta              // publication details
+	candidates []Position        // scratch buffer reused by findBestPosition
+	starts     []Position        // scratch buffer reused by the fast scan
+	marks      []bool            // scratch start marks reused by the fast scan
+	frontier   map[[2]int]bool   // empty cells next to letters, nil until needed
+	memo       openMemo          // open runs of the running scan
+	words      []string          // scratch copy of the words being generated
+	seen       map[string]bool   // scratch set used to drop repeated words
+	stack      []searchFrame     // scratch stack reused by search
+	shortest   []int             // scratch shortest word lengths reused by search
+	numbering  [][2]int          // scratch start cells reused by AssignNumbers
+	spiralScan spiralOrder       // spiral scan order of the last grid size scanned
+	rebus      map[[2]int]string // cells holding several letters, by {row, col}
 	hCount     int
 	vCount     int
 }
@@ -65,85 +86,187 @@ func NewCrossword(width, height int) *Crossword {
 	return c
 }
 
-// isValidPosition checks if the given coordinates are within the board
+// NewCrosswordE is NewCrossword with the dimensions validated, returning
+// an error instead of a crossword unusable for anything
+func NewCrosswordE(width, height int) (*Crossword, error) {
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("invalid dimensions %dx%d", width, height)
+	}
+	return NewCrossword(width, height), nil
+}
+
+// Reset empties the crossword for a new generation, keeping template
+// blocks. The board, word-id arrays, placements and the scratch buffers of
+// the search are all reused, so once they have grown a reset followed by a
+// generation of the same words allocates next to nothing.
+func (c *Crossword) Reset() {
+	for i := 0; i < c.height; i++ {
+		for j := 0; j < c.width; j++ {
+			c.board[i][j] = ' '
+			if c.isTemplateBlock(i, j) {
+				c.board[i][j] = '*'
+			}
+			c.hWords[i][j] = 0
+			c.vWords[i][j] = 0
+		}
+	}
+
+	clear(c.usedWords)
+	clear(c.rebus)
+	c.frontier = nil
+	c.placements = c.placements[:0]
+	c.unplaced = c.unplaced[:0]
+	c.hCount = 0
+	c.vCount = 0
+}
+
+// Clone returns a deep copy of the crossword
+func (c *Crossword) Clone() *Crossword {
+	clone := &Crossword{
+		width:      c.width,
+		height:     c.height,
+		usedWords:  make(map[string]bool, len(c.usedWords)),
+		placements: append([]WordPlacement(nil), c.placements...),
+		unplaced:   append([]string(nil), c.unplaced...),
+		opts:       c.opts,
+		meta:       c.meta,
+		hCount:     c.hCount,
+		vCount:     c.vCount,
+	}
+
+	for word := range c.usedWords {
+		clone.usedWords[word] = true
+	}
+
+	if c.rebus != nil {
+		clone.rebus = make(map[[2]int]string, len(c.rebus))
+		for cell, letters := range c.rebus {
+			clone.rebus[cell] = letters
+		}
+	}
+
+	clone.board = make([][]rune, c.height)
+	clone.hWords = make([][]int, c.height)
+	clone.vWords = make([][]int, c.height)
+	for i := 0; i < c.height; i++ {
+		clone.board[i] = append([]rune(nil), c.board[i]...)
+		clone.hWords[i] = append([]int(nil), c.hWords[i]...)
+		clone.vWords[i] = append([]int(nil), c.vWords[i]...)
+	}
+
+	if c.template != nil {
+		clone.template = make([][]bool, c.height)
+		for i := range c.template {
+			clone.template[i] = append([]bool(nil), c.template[i]...)
+		}
+	}
+	if c.mask != nil {
+		clone.mask = make([][]bool, c.height)
+		for i := range c.mask {
+			clone.mask[i] = append([]bool(nil), c.mask[i]...)
+		}
+	}
+
+	return clone
+}
+
+// isValidPosition checks if the given coordinates are within the board and
+// not masked out
 func (c *Crossword) isValidPosition(x, y int) bool {
-	return x >= 0 && x < c.height && y >= 0 && y < c.width
+	return x >= 0 && x < c.height && y >= 0 && y < c.width && (c.mask == nil || c.mask[x][y])
+}
+
+// inEdgeMargin checks if the given coordinates fall within the configured edge margin
+func (c *Crossword) inEdgeMargin(x, y int) bool {
+	m := c.opts.EdgeMargin
+	return x < m || x >= c.height-m || y < m || y >= c.width-m
 }
 
 // canBePlaced checks if a word can be placed at the given position
 func (c *Crossword) canBePlaced(word string, x, y int, dir Direction) int {
 	intersections := 0
 
-	if dir == Horizontal {
-		// Check horizontal placement
-		for j := 0; j < len(word); j++ {
-			x1, y1 := x, y+j
+	if !c.runOpen(x, y, len(word), dir) {
+		return -1
+	}
 
-			if !c.isValidPosition(x1, y1) {
-				return -1
-			}
+	p := WordPlacement{X: x, Y: y, Dir: dir, Length: len(word)}
+	for j := 0; j < len(word); j++ {
+		x1, y1 := p.cell(j)
 
-			// Check if space is empty or matches letter
-			if c.board[x1][y1] != ' ' && c.board[x1][y1] != rune(word[j]) {
-				return -1
-			}
+		// Check if space is empty or matches letter
+		if c.board[x1][y1] != ' ' && c.board[x1][y1] != rune(word[j]) {
+			return -1
+		}
 
-			// Check adjacent words
-			if c.isValidPosition(x1-1, y1) && c.hWords[x1-1][y1] > 0 {
-				return -1
-			}
-			if c.isValidPosition(x1+1, y1) && c.hWords[x1+1][y1] > 0 {
-				return -1
-			}
+		// A single letter never matches a rebus cell
+		if _, ok := c.rebus[[2]int{x1, y1}]; ok {
+			return -1
+		}
 
-			if c.board[x1][y1] == rune(word[j]) {
-				intersections++
-			}
+		if c.board[x1][y1] == rune(word[j]) {
+			intersections++
 		}
-	} else {
-		// Check vertical placement
-		for j := 0; j < len(word); j++ {
-			x1, y1 := x+j, y
+	}
 
-			if !c.isValidPosition(x1, y1) {
-				return -1
-			}
+	if !c.endsOpen(x, y, len(word), dir) {
+		return -1
+	}
 
-			if c.board[x1][y1] != ' ' && c.board[x1][y1] != rune(word[j]) {
-				return -1
-			}
+	return intersections
+}
 
-			if c.isValidPosition(x1, y1-1) && c.vWords[x1][y1-1] > 0 {
-				return -1
-			}
-			if c.isValidPosition(x1, y1+1) && c.vWords[x1][y1+1] > 0 {
-				return -1
-			}
+// cellOpen checks if a word running in the given direction may pass
+// through a cell: it must be usable, not already inside a word running the
+// same way, and not alongside a parallel word
+func (c *Crossword) cellOpen(x, y int, dir Direction) bool {
+	if !c.isValidPosition(x, y) || c.inEdgeMargin(x, y) {
+		return false
+	}
 
-			if c.board[x1][y1] == rune(word[j]) {
-				intersections++
-			}
+	if dir == Horizontal {
+		if c.hWords[x][y] > 0 {
+			return false
+		}
+		if c.isValidPosition(x-1, y) && c.hWords[x-1][y] > 0 {
+			return false
+		}
+		if c.isValidPosition(x+1, y) && c.hWords[x+1][y] > 0 {
+			return false
+		}
+	} else {
+		if c.vWords[x][y] > 0 {
+			return false
+		}
+		if c.isValidPosition(x, y-1) && c.vWords[x][y-1] > 0 {
+			return false
+		}
+		if c.isValidPosition(x, y+1) && c.vWords[x][y+1] > 0 {
+			return false
 		}
 	}
 
-	// Check spaces before and after word
+	return true
+}
+
+// endsOpen checks that the spaces before and after a word are empty or blocks
+func (c *Crossword) endsOpen(x, y, length int, dir Direction) bool {
 	if dir == Horizontal {
 		if c.isValidPosition(x, y-1) && c.board[x][y-1] != ' ' && c.board[x][y-1] != '*' {
-			return -1
+			return false
 		}
-		if c.isValidPosition(x, y+len(word)) && c.board[x][y+len(word)] != ' ' && c.board[x][y+len(word)] != '*' {
-			return -1
+		if c.isValidPosition(x, y+length) && c.board[x][y+length] != ' ' && c.board[x][y+length] != '*' {
+			return false
 		}
 	} else {
 		if c.isValidPosition(x-1, y) && c.board[x-1][y] != ' ' && c.board[x-1][y] != '*' {
-			return -1
+			return false
 		}
-		if c.isValidPosition(x+len(word), y) && c.board[x+len(word)][y] != ' ' && c.board[x+len(word)][y] != '*' {
-			return -1
+		if c.isValidPosition(x+length, y) && c.board[x+length][y] != ' ' && c.board[x+length][y] != '*' {
+			return false
 		}
 	}
-
-	return intersections
+	return true
 }
 
 // putWord places a word on the board
@@ -202,115 +325,495 @@ func (c *Crossword) putWord(word string, x, y int, dir Direction) {
 			c.board[x+len(word)][y] = '*'
 		}
 	}
+
+	c.updateFrontier(WordPlacement{X: x, Y: y, Dir: dir, Length: len(word)})
+}
+
+// findBestPosition finds the best position for a word, false when it has none
+func (c *Crossword) findBestPosition(word string) (Position, bool) {
+	directions := c.openDirections()
+
+	fast := c.opts.FastScan && len(c.placements) > 0
+	bestPositions, maxIntersections := c.scanPositions(word, directions, fast, c.candidates[:0])
+
+	// Keep the grown buffer for the next word
+	c.candidates = bestPositions
+
+	if len(bestPositions) == 0 {
+		return Position{}, false
+	}
+
+	// Short words must tie existing ones together rather than sprawl
+	if c.opts.PreferLong && len(word) <= shortWordLength && len(c.placements) > 0 && maxIntersections < 2 {
+		return Position{}, false
+	}
+
+	if c.opts.AvoidDeadEnds {
+		bestPositions = c.fewestDeadEnds(word, bestPositions)
+	}
+	if c.opts.PreferOpenSpace {
+		bestPositions = c.mostOpenNeighbors(word, bestPositions)
+	}
+	if c.opts.EndpointBias {
+		bestPositions = c.smallestBounds(word, c.nearEndpoints(word, bestPositions))
+	}
+
+	// Candidates are in scan order, horizontal before vertical
+	var best Position
+	if c.opts.DeterministicTieBreak || c.opts.ScanOrder == ScanSpiral {
+		best = bestPositions[0]
+	} else {
+		// Narrow the ties down to one direction when biased
+		if c.opts.DirectionBias > 0 {
+			bestPositions = c.biasDirection(bestPositions)
+		}
+
+		// Pick a random position from the best ones
+		best = bestPositions[c.randIntn(len(bestPositions))]
+	}
+
+	return best, true
 }
 
-// findBestPosition finds the best position for a word
-func (c *Crossword) findBestPosition(word string) *Position {
-	var bestPositions []Position
+// fits checks if the word has any valid position on the board. Unlike
+// findBestPosition it draws nothing from the random source, so checking
+// leaves the generation unchanged.
+func (c *Crossword) fits(word string) bool {
+	positions, _ := c.scanPositions(word, c.openDirections(), false, c.candidates[:0])
+	c.candidates = positions
+	return len(positions) > 0
+}
+
+// shortWordLength is the longest word PreferLong treats as short
+const shortWordLength = 4
+
+// scanPositions returns the positions with the most intersections in scan
+// order, appended to positions, and that intersection count. The fast scan
+// only tries the starts crossing a letter on the board.
+func (c *Crossword) scanPositions(word string, directions []Direction, fast bool, positions []Position) ([]Position, int) {
 	maxIntersections := -1
 
+	c.beginScan()
+	defer c.endScan()
+
+	try := func(x, y int, dir Direction) {
+		intersections := c.canBePlaced(word, x, y, dir)
+		if intersections < 0 {
+			return
+		}
+
+		// Crossings past the cap don't improve a position
+		if c.opts.IntersectionCap > 0 {
+			intersections = min(intersections, c.opts.IntersectionCap)
+		}
+
+		if intersections > maxIntersections {
+			maxIntersections = intersections
+			positions = positions[:0]
+		}
+
+		if intersections == maxIntersections {
+			positions = append(positions, Position{X: x, Y: y, Dir: dir})
+		}
+	}
+
+	if fast {
+		for _, start := range c.crossingStarts(word, directions) {
+			try(start.X, start.Y, start.Dir)
+		}
+
+		// Sorting the best positions alone is enough to match the full scan
+		c.sortScanOrder(positions)
+		return positions, maxIntersections
+	}
+
 	// Try all possible positions
+	if c.opts.ScanOrder == ScanSpiral {
+		for _, cell := range c.spiral().cells {
+			for _, dir := range directions {
+				try(cell[0], cell[1], dir)
+			}
+		}
+		return positions, maxIntersections
+	}
+
 	for x := 0; x < c.height; x++ {
 		for y := 0; y < c.width; y++ {
-			for _, dir := range []Direction{Horizontal, Vertical} {
-				intersections := c.canBePlaced(word, x, y, dir)
-				if intersections < 0 {
-					continue
-				}
-
-				if intersections > maxIntersections {
-					maxIntersections = intersections
-					bestPositions = bestPositions[:0]
-				}
-
-				if intersections == maxIntersections {
-					bestPositions = append(bestPositions, Position{X: x, Y: y, Dir: dir})
-				}
+			for _, dir := range directions {
+				try(x, y, dir)
 			}
 		}
 	}
+	return positions, maxIntersections
+}
 
-	if len(bestPositions) == 0 {
+// biasDirection keeps only the across or only the down positions, picking
+// across with probability DirectionBias when both are available
+func (c *Crossword) biasDirection(positions []Position) []Position {
+	var across, down []Position
+	for _, p := range positions {
+		if p.Dir == Horizontal {
+			across = append(across, p)
+		} else {
+			down = append(down, p)
+		}
+	}
+	if len(across) == 0 || len(down) == 0 {
+		return positions
+	}
+
+	if float64(c.randIntn(1000)) < c.opts.DirectionBias*1000 {
+		return across
+	}
+	return down
+}
+
+// bothDirections lists the directions in scan order. openDirections
+// returns subslices of it, which callers must not modify.
+var bothDirections = []Direction{Horizontal, Vertical}
+
+// openDirections returns the directions still below their word limit
+func (c *Crossword) openDirections() []Direction {
+	if c.opts.MaxWords > 0 && len(c.placements) >= c.opts.MaxWords {
 		return nil
 	}
 
-	// Return a random position from the best ones
-	return &bestPositions[rand.Intn(len(bestPositions))]
+	across, down := c.CountByDirection()
+	acrossOpen := c.opts.MaxAcross <= 0 || across < c.opts.MaxAcross
+	downOpen := c.opts.MaxDown <= 0 || down < c.opts.MaxDown
+
+	switch {
+	case acrossOpen && downOpen:
+		return bothDirections
+	case acrossOpen:
+		return bothDirections[:1]
+	case downOpen:
+		return bothDirections[1:]
+	}
+	return nil
+}
+
+// randIntn returns a random number in [0, n) from the configured source
+func (c *Crossword) randIntn(n int) int {
+	if c.opts.Rand != nil {
+		return c.opts.Rand(n)
+	}
+	return rand.Intn(n)
 }
 
-// GeneratePuzzle generates a crossword puzzle from a list of words
+// GeneratePuzzle generates a crossword puzzle from a list of words and
+// reports whether every word was placed. Words that don't fit are still
+// skipped; use GenerateWithOptions for the detailed result.
 func (c *Crossword) GeneratePuzzle(words []string) bool {
-	rand.Seed(time.Now().UnixNano())
+	result := c.GenerateWithOptions(words, GenerateOptions{})
+	return result.Success && len(result.Unplaced) == 0
+}
 
-	startTime := time.Now()
-	maxTime := 1 * time.Minute
+// GenerateWithOptions generates a crossword puzzle honoring the given
+// options. A repeated word is attempted once and words already on the
+// board are left out, so neither counts as unplaced.
+func (c *Crossword) GenerateWithOptions(words []string, opts GenerateOptions) GenerateResult {
+	c.unplaced = c.unplaced[:0]
+	c.opts = opts
+
+	// Work on a copy, the caller's slice may be the last unplaced words
+	words, banned := filterBanned(words, opts.Banned)
+	if c.seen == nil {
+		c.seen = make(map[string]bool)
+	}
+	c.words = uniqueWords(c.words[:0], words, c.usedWords, c.seen)
+	words = c.words
+	orderWords(words, opts)
+
+	// Fail fast when the first word doesn't fit the empty board and can't
+	// be skipped, or when no word fits it at all
+	if len(words) > 0 && len(c.placements) == 0 && !c.fits(words[0]) {
+		reason := ""
+		if opts.RequireAll {
+			reason = fmt.Sprintf("first word %q does not fit the %dx%d grid", words[0], c.width, c.height)
+		} else if !slices.ContainsFunc(words[1:], c.fits) {
+			reason = fmt.Sprintf("no word fits the %dx%d grid", c.width, c.height)
+		}
 
-	var generate func(pos int) bool
-	generate = func(pos int) bool {
-		if pos >= len(words) {
-			return true
+		if reason != "" {
+			c.unplaced = append(c.unplaced, words...)
+			return GenerateResult{Unplaced: c.unplaced, Banned: banned, Reason: reason}
 		}
+	}
 
-		if time.Since(startTime) > maxTime {
-			return false
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = 1 * time.Minute
+	}
+	deadline := time.Now().Add(timeout)
+
+	result := GenerateResult{Success: c.search(words, deadline), Banned: banned}
+	if !result.Success {
+		// Backtracking rolled every placement back
+		c.unplaced = append(c.unplaced[:0], words...)
+		result.Reason = "generation timed out"
+		if opts.RequireAll {
+			result.Reason = "not every word could be placed"
 		}
+	}
+
+	result.Unplaced = c.unplaced
+	result.Placed = len(words) - len(c.unplaced)
+	result.FillRatio = 1
+	if len(words) > 0 {
+		result.FillRatio = float64(result.Placed) / float64(len(words))
+	}
+	if len(c.placements) > 0 {
+		total := 0
+		for _, p := range c.placements {
+			total += p.Length
+		}
+		result.AverageLength = float64(total) / float64(len(c.placements))
+	}
+
+	// Reject sparse results so the caller can retry
+	if result.Success && result.FillRatio < opts.MinFillRatio {
+		result.Success = false
+		result.Reason = fmt.Sprintf("fill ratio %.2f is below %.2f", result.FillRatio, opts.MinFillRatio)
+	}
+	if result.Success && opts.MaxBlockRatio > 0 && c.BlockRatio() > opts.MaxBlockRatio {
+		result.Success = false
+		result.Reason = fmt.Sprintf("block ratio %.2f exceeds %.2f", c.BlockRatio(), opts.MaxBlockRatio)
+	}
+
+	c.AssignNumbers()
+
+	return result
+}
+
+// Stages of a search frame
+const (
+	stageEnter   = iota // word not yet attempted
+	stagePlaced         // word placed, exploring the following words
+	stageSkipped        // word skipped, exploring the following words
+)
 
-		word := words[pos]
-		if bestPos := c.findBestPosition(word); bestPos != nil {
-			// Try placing the word
-			c.putWord(word, bestPos.X, bestPos.Y, bestPos.Dir)
+// searchFrame is the state of one word in the backtracking search
+type searchFrame struct {
+	pos     int
+	stage   int
+	bestPos Position
+}
 
-			if generate(pos + 1) {
+// timeoutCheckInterval is how many search steps run between clock reads
+const timeoutCheckInterval = 64
+
+// search places the words by backtracking over an explicit stack, so
+// memory grows with the word count rather than the goroutine stack. Each
+// word is first tried at its best position, then skipped.
+func (c *Crossword) search(words []string, deadline time.Time) bool {
+	stack := append(c.stack[:0], searchFrame{pos: 0})
+	defer func() { c.stack = stack[:0] }()
+	timedOut := false
+	c.shortest = shortestFrom(c.shortest[:0], words)
+	shortest := c.shortest
+
+	for steps := 1; len(stack) > 0; steps++ {
+		f := &stack[len(stack)-1]
+
+		switch f.stage {
+		case stageEnter:
+			if f.pos >= len(words) {
 				return true
 			}
 
-			// If placing didn't work, remove it and try next position
-			c.removeWord(word, bestPos.X, bestPos.Y, bestPos.Dir)
+			if !timedOut && steps%timeoutCheckInterval == 0 {
+				timedOut = time.Now().After(deadline)
+			}
+			if timedOut {
+				stack = stack[:len(stack)-1]
+				continue
+			}
+
+			word := words[f.pos]
+			bestPos, ok := c.findBestPosition(word)
+
+			if ok {
+				// Try placing the word
+				f.bestPos = bestPos
+				c.putWord(word, bestPos.X, bestPos.Y, bestPos.Dir)
+				c.logEvent(ActionPlace, word, &f.bestPos)
+				f.stage = stagePlaced
+			} else if c.opts.RequireAll {
+				// Skipping is not allowed, backtrack
+				stack = stack[:len(stack)-1]
+				continue
+			} else if c.saturated(shortest[f.pos]) {
+				// Nothing else can fit, skip the remaining words at once
+				c.unplaced = append(c.unplaced, words[f.pos:]...)
+				for _, rest := range words[f.pos:] {
+					c.logEvent(ActionSkip, rest, nil)
+				}
+				return true
+			} else {
+				// Try skipping this word
+				c.unplaced = append(c.unplaced, word)
+				c.logEvent(ActionSkip, word, nil)
+				f.stage = stageSkipped
+			}
+			stack = append(stack, searchFrame{pos: f.pos + 1})
+
+		case stagePlaced:
+			// If placing didn't work, remove it and try skipping the word
+			word := words[f.pos]
+			c.removeWord(word, f.bestPos.X, f.bestPos.Y, f.bestPos.Dir)
+			c.logEvent(ActionRemove, word, &f.bestPos)
+			if c.opts.RequireAll {
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			c.unplaced = append(c.unplaced, word)
+			c.logEvent(ActionSkip, word, nil)
+			f.stage = stageSkipped
+			stack = append(stack, searchFrame{pos: f.pos + 1})
+
+		case stageSkipped:
+			// Neither worked, backtrack to the previous word
+			c.unplaced = c.unplaced[:len(c.unplaced)-1]
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	return false
+}
+
+// saturated reports whether none of the remaining words can fit anymore,
+// given the length of the shortest: it is longer than every run of open
+// cells. A word of two or more letters needs at least one empty cell in
+// its run, since two adjacent letters of crossing words would form
+// parallel neighbors.
+func (c *Crossword) saturated(shortest int) bool {
+	return shortest >= 2 && !c.hasOpenRun(shortest)
+}
+
+// shortestFrom fills dst with, for each position, the length of the
+// shortest of the words from there on
+func shortestFrom(dst []int, words []string) []int {
+	shortest := slices.Grow(dst, len(words))[:len(words)]
+	for i := len(words) - 1; i >= 0; i-- {
+		shortest[i] = len(words[i])
+		if i+1 < len(words) {
+			shortest[i] = min(shortest[i], shortest[i+1])
+		}
+	}
+	return shortest
+}
+
+// hasOpenRun checks for a line of at least n cells, across or down, a word
+// may run through with an empty cell among them. Blocks, masked-out cells
+// and the edge margin end a run.
+func (c *Crossword) hasOpenRun(n int) bool {
+	for _, dir := range []Direction{Horizontal, Vertical} {
+		lines, length := c.height, c.width
+		if dir == Vertical {
+			lines, length = c.width, c.height
+		}
+
+		for i := 0; i < lines; i++ {
+			run, empty := 0, false
+			for j := 0; j < length; j++ {
+				x, y := i, j
+				if dir == Vertical {
+					x, y = j, i
+				}
+				if c.board[x][y] == '*' || !c.cellOpen(x, y, dir) {
+					run, empty = 0, false
+					continue
+				}
+				run++
+				empty = empty || c.board[x][y] == ' '
+				if run >= n && empty {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// Fill places new words around the ones already on the board, which stay
+// fixed. Words already placed are ignored.
+func (c *Crossword) Fill(words []string) GenerateResult {
+	return c.GenerateWithOptions(words, c.opts)
+}
+
+// RegenerateKeeping removes every placed word except the kept ones, which
+// stay fixed, and fills around them with the new words. Kept words that
+// are not placed fail the regeneration without touching the board.
+func (c *Crossword) RegenerateKeeping(keep []string, newWords []string) GenerateResult {
+	kept := make(map[string]bool, len(keep))
+	for _, word := range keep {
+		if !c.usedWords[word] {
+			return GenerateResult{Reason: fmt.Sprintf("kept word %q is not placed", word)}
 		}
+		kept[word] = true
+	}
 
-		// Try skipping this word
-		return generate(pos + 1)
+	placements := append([]WordPlacement(nil), c.placements...)
+	for _, p := range placements {
+		if !kept[p.Word] {
+			c.removeWord(p.Word, p.X, p.Y, p.Dir)
+		}
 	}
 
-	return generate(0)
+	return c.Fill(newWords)
 }
 
 // removeWord removes a word from the board
 func (c *Crossword) removeWord(word string, x, y int, dir Direction) {
 	delete(c.usedWords, word)
 
-	for i := 0; i < len(word); i++ {
+	// Rebus words span fewer cells than letters
+	length := len(word)
+	for i, p := range c.placements {
+		if p.Word == word && p.X == x && p.Y == y && p.Dir == dir {
+			length = p.Length
+			c.placements = append(c.placements[:i], c.placements[i+1:]...)
+			break
+		}
+	}
+
+	for i := 0; i < length; i++ {
 		var x1, y1 int
 		if dir == Horizontal {
 			x1, y1 = x, y+i
 			c.hWords[x1][y1] = 0
 			if c.vWords[x1][y1] == 0 {
 				c.board[x1][y1] = ' '
+				delete(c.rebus, [2]int{x1, y1})
 			}
 		} else {
 			x1, y1 = x+i, y
 			c.vWords[x1][y1] = 0
 			if c.hWords[x1][y1] == 0 {
 				c.board[x1][y1] = ' '
+				delete(c.rebus, [2]int{x1, y1})
 			}
 		}
 	}
 
 	// Remove blocking characters if no other words are adjacent
 	if dir == Horizontal {
-		if c.isValidPosition(x, y-1) && !c.hasAdjacentWords(x, y-1) {
-			c.board[x][y-1] = ' '
-		}
-		if c.isValidPosition(x, y+len(word)) && !c.hasAdjacentWords(x, y+len(word)) {
-			c.board[x][y+len(word)] = ' '
-		}
+		c.clearBlock(x, y-1)
+		c.clearBlock(x, y+length)
 	} else {
-		if c.isValidPosition(x-1, y) && !c.hasAdjacentWords(x-1, y) {
-			c.board[x-1][y] = ' '
-		}
-		if c.isValidPosition(x+len(word), y) && !c.hasAdjacentWords(x+len(word), y) {
-			c.board[x+len(word)][y] = ' '
-		}
+		c.clearBlock(x-1, y)
+		c.clearBlock(x+length, y)
+	}
+
+	c.updateFrontier(WordPlacement{X: x, Y: y, Dir: dir, Length: length})
+}
+
+// clearBlock empties a blocking cell unless a word still needs it or it
+// belongs to the template
+func (c *Crossword) clearBlock(x, y int) {
+	if c.isValidPosition(x, y) && !c.isTemplateBlock(x, y) && !c.hasAdjacentWords(x, y) {
+		c.board[x][y] = ' '
 	}
 }
 
@@ -335,3 +838,8 @@ func (c *Crossword) GetBoard() [][]rune {
 func (c *Crossword) GetPlacements() []WordPlacement {
 	return c.placements
 }
+
+// UnplacedWords returns the words skipped during the last generation
+func (c *Crossword) UnplacedWords() []string {
+	return c.unplaced
+}
@@ -0,0 +1,977 @@
+package utils
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUnplacedWords(t *testing.T) {
+	tests := []struct {
+		name     string
+		width    int
+		height   int
+		words    []string
+		unplaced []string
+	}{
+		{"all fit", 10, 10, []string{"CASA", "CANE"}, nil},
+		{"too long", 5, 5, []string{"CASA", "ELEFANTE", "ASSO"}, []string{"ELEFANTE"}},
+		{"nothing fits", 3, 3, []string{"ELEFANTE"}, []string{"ELEFANTE"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCrossword(tt.width, tt.height)
+			c.GenerateWithOptions(tt.words, GenerateOptions{})
+
+			got := c.UnplacedWords()
+			for _, word := range tt.unplaced {
+				if !slices.Contains(got, word) {
+					t.Errorf("UnplacedWords() = %v, want it to contain %q", got, word)
+				}
+			}
+			for _, p := range c.GetPlacements() {
+				if slices.Contains(got, p.Word) {
+					t.Errorf("placed word %q reported as unplaced", p.Word)
+				}
+			}
+			if len(got)+len(c.GetPlacements()) != len(tt.words) {
+				t.Errorf("%d placed + %d unplaced, want %d words", len(c.GetPlacements()), len(got), len(tt.words))
+			}
+		})
+	}
+}
+
+// crossingPuzzle returns a 6x6 puzzle with CASA across and CANE down, both
+// starting at row 1, column 1
+func crossingPuzzle() *Crossword {
+	c := NewCrossword(6, 6)
+	c.putWord("CASA", 1, 1, Horizontal)
+	c.putWord("CANE", 1, 1, Vertical)
+	c.AssignNumbers()
+	return c
+}
+
+func TestMinFillRatio(t *testing.T) {
+	// ELEFANTE can't fit, so half the words are placed
+	words := []string{"CASA", "ELEFANTE"}
+
+	tests := []struct {
+		name     string
+		minRatio float64
+		success  bool
+	}{
+		{"disabled", 0, true},
+		{"met", 0.5, true},
+		{"not met", 0.9, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCrossword(5, 5)
+			result := c.GenerateWithOptions(words, GenerateOptions{MinFillRatio: tt.minRatio})
+
+			if result.FillRatio != 0.5 {
+				t.Errorf("FillRatio = %v, want 0.5", result.FillRatio)
+			}
+			if result.Success != tt.success {
+				t.Errorf("Success = %v, want %v (reason %q)", result.Success, tt.success, result.Reason)
+			}
+			if !result.Success && result.Reason == "" {
+				t.Error("failed without a reason")
+			}
+		})
+	}
+}
+
+func TestFillKeepsPlacedWords(t *testing.T) {
+	tests := []struct {
+		name  string
+		words []string
+	}{
+		{"crossing words", []string{"TOPO", "ORSO", "ATOMO"}},
+		{"unrelated words", []string{"BUE", "RE", "DIVANO"}},
+		{"already placed", []string{"GATTO", "TOPO"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCrossword(10, 10)
+			c.putWord("GATTO", 4, 2, Horizontal)
+
+			result := c.Fill(tt.words)
+			if !result.Success {
+				t.Fatalf("Fill() failed: %s", result.Reason)
+			}
+
+			p := placementOf(t, c, "GATTO")
+			if p.X != 4 || p.Y != 2 || p.Dir != Horizontal {
+				t.Errorf("GATTO moved to (%d,%d) dir %d", p.X, p.Y, p.Dir)
+			}
+			for i, r := range "GATTO" {
+				if got := c.GetBoard()[4][2+i]; got != r {
+					t.Errorf("cell (4,%d) = %q, want %q", 2+i, got, r)
+				}
+			}
+			if !c.PlacementsConsistent() {
+				t.Error("placements don't match the board")
+			}
+		})
+	}
+}
+
+// testWords is a small Italian vocabulary shared by the generation tests
+var testWords = []string{
+	"GATTO", "TOPO", "ORSO", "CANE", "PAPPAGALLO", "ELEFANTE", "TIGRE",
+	"LEONE", "CAVALLO", "PECORA", "MUCCA", "GALLINA", "VOLPE", "LUPO",
+}
+
+func TestEdgeMargin(t *testing.T) {
+	for _, margin := range []int{1, 2} {
+		t.Run(fmt.Sprintf("margin %d", margin), func(t *testing.T) {
+			c := NewCrossword(10, 10)
+			rng := rand.New(rand.NewSource(1))
+			c.GenerateWithOptions(testWords, GenerateOptions{EdgeMargin: margin, Rand: rng.Intn})
+
+			if len(c.GetPlacements()) == 0 {
+				t.Fatal("nothing placed")
+			}
+			for _, p := range c.GetPlacements() {
+				for i := 0; i < p.Length; i++ {
+					x, y := p.cell(i)
+					if x < margin || x >= 10-margin || y < margin || y >= 10-margin {
+						t.Errorf("%s has cell (%d,%d) inside the margin", p.Word, x, y)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDeterministicTieBreak(t *testing.T) {
+	tests := []struct {
+		name         string
+		seedA, seedB int64
+	}{
+		{"same seed", 1, 1},
+		{"different seeds", 1, 2},
+		{"far seeds", 7, 12345},
+	}
+
+	generate := func(seed int64) *Crossword {
+		c := NewCrossword(12, 12)
+		rng := rand.New(rand.NewSource(seed))
+		c.GenerateWithOptions(testWords, GenerateOptions{DeterministicTieBreak: true, Rand: rng.Intn})
+		return c
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := generate(tt.seedA), generate(tt.seedB)
+			if !a.Equal(b) {
+				t.Errorf("grids differ:\n%s\n%s", RenderTerminal(a, false), RenderTerminal(b, false))
+			}
+		})
+	}
+}
+
+func TestMaxBlockRatio(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxRatio float64
+		success  bool
+	}{
+		{"disabled", 0, true},
+		{"loose", 1, true},
+		{"tight", 0.01, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCrossword(10, 10)
+			result := c.GenerateWithOptions([]string{"CASA", "CANE"}, GenerateOptions{MaxBlockRatio: tt.maxRatio})
+
+			if result.Success != tt.success {
+				t.Errorf("Success = %v with block ratio %.2f, want %v", result.Success, c.BlockRatio(), tt.success)
+			}
+		})
+	}
+}
+
+// recursiveGenerate is the recursive search that preceded the explicit
+// stack, kept as a reference for its output
+func recursiveGenerate(c *Crossword, words []string, opts GenerateOptions) {
+	c.opts = opts
+
+	var generate func(pos int) bool
+	generate = func(pos int) bool {
+		if pos >= len(words) {
+			return true
+		}
+
+		word := words[pos]
+		if bestPos, ok := c.findBestPosition(word); ok {
+			c.putWord(word, bestPos.X, bestPos.Y, bestPos.Dir)
+			if generate(pos + 1) {
+				return true
+			}
+			c.removeWord(word, bestPos.X, bestPos.Y, bestPos.Dir)
+		}
+
+		c.unplaced = append(c.unplaced, word)
+		if generate(pos + 1) {
+			return true
+		}
+		c.unplaced = c.unplaced[:len(c.unplaced)-1]
+		return false
+	}
+
+	generate(0)
+	c.AssignNumbers()
+}
+
+// dataWords returns every word of the bundled word file, repeats included
+func dataWords(t testing.TB) []string {
+	t.Helper()
+	var words []string
+	err := StreamWords("../assets/data.json", func(item Data) bool {
+		words = append(words, item.Nome)
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return words
+}
+
+func TestSearchMatchesRecursive(t *testing.T) {
+	tests := []struct {
+		name  string
+		size  int
+		words []string
+		long  bool
+	}{
+		{"small", 10, testWords, false},
+		{"repeats", 10, append(append([]string(nil), testWords...), testWords...), false},
+		{"word file", 15, dataWords(t), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.long && testing.Short() {
+				t.Skip("long word list")
+			}
+
+			for seed := int64(1); seed <= 3; seed++ {
+				c := NewCrossword(tt.size, tt.size)
+				result := c.GenerateWithOptions(tt.words, GenerateOptions{Rand: rand.New(rand.NewSource(seed)).Intn})
+				if !result.Success {
+					t.Fatalf("seed %d: generation failed: %s", seed, result.Reason)
+				}
+
+				want := NewCrossword(tt.size, tt.size)
+				recursiveGenerate(want, uniqueWords(nil, tt.words, nil, make(map[string]bool)), GenerateOptions{Rand: rand.New(rand.NewSource(seed)).Intn})
+
+				if !c.Equal(want) {
+					t.Errorf("seed %d: grids differ:\n%s\n%s", seed, RenderTerminal(c, false), RenderTerminal(want, false))
+				}
+				if !slices.Equal(c.UnplacedWords(), want.UnplacedWords()) {
+					t.Errorf("seed %d: %d words unplaced, want %d", seed, len(c.UnplacedWords()), len(want.UnplacedWords()))
+				}
+			}
+		})
+	}
+}
+
+func TestSearchMemory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long word list")
+	}
+	words := dataWords(t)
+
+	tests := []struct {
+		name  string
+		words []string
+	}{
+		{"thousand words", words[:1000]},
+		{"ten thousand words", words[:10000]},
+		{"word file", words},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCrossword(15, 15)
+			generate := func() {
+				c.GenerateWithOptions(tt.words, GenerateOptions{Rand: rand.New(rand.NewSource(1)).Intn})
+			}
+
+			// Memory grows with the word count, by about 500 bytes a word
+			var before, after runtime.MemStats
+			runtime.GC()
+			runtime.ReadMemStats(&before)
+			generate()
+			runtime.ReadMemStats(&after)
+			if got, limit := after.TotalAlloc-before.TotalAlloc, uint64(1024*len(tt.words)); got > limit {
+				t.Errorf("allocated %d bytes for %d words, want at most %d", got, len(tt.words), limit)
+			}
+
+			// Generating again reuses the scratch buffers, whatever the count
+			if allocs := testing.AllocsPerRun(1, generate); allocs > 10 {
+				t.Errorf("%v allocations generating again, want at most 10", allocs)
+			}
+		})
+	}
+}
+
+func TestGenerateRepeatedWords(t *testing.T) {
+	tests := []struct {
+		name   string
+		words  []string
+		placed int
+	}{
+		{"repeated word", []string{"CASA", "CANE", "CASA"}, 2},
+		{"all repeated", []string{"CASA", "CASA", "CASA"}, 1},
+		{"different case", []string{"CASA", "casa", "CANE"}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCrossword(10, 10)
+			if !c.GeneratePuzzle(tt.words) {
+				t.Errorf("GeneratePuzzle() = false, unplaced %v", c.UnplacedWords())
+			}
+			if got := len(c.GetPlacements()); got != tt.placed {
+				t.Errorf("%d words placed, want %d", got, tt.placed)
+			}
+			if !c.PlacementsConsistent() {
+				t.Error("placements don't match the board")
+			}
+		})
+	}
+}
+
+// BenchmarkGenerate resets and regenerates one crossword. Once the first
+// run has grown the scratch buffers an iteration allocates nothing: the
+// board, word-id arrays, word copy, search stack and numbering are reused.
+func BenchmarkGenerate(b *testing.B) {
+	c := NewCrossword(15, 15)
+	opts := GenerateOptions{Rand: rand.New(rand.NewSource(1)).Intn}
+
+	// The first run grows the scratch buffers
+	c.GenerateWithOptions(testWords, opts)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Reset()
+		c.GenerateWithOptions(testWords, opts)
+	}
+}
+
+func TestReset(t *testing.T) {
+	templated := NewCrossword(10, 10)
+	if err := templated.SetTemplate(blockGrid(10, 10, [2]int{4, 4})); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		c      *Crossword
+		blocks int
+	}{
+		{"plain", NewCrossword(10, 10), 0},
+		{"template", templated, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.c.GenerateWithOptions(testWords, GenerateOptions{})
+			first := &tt.c.GetBoard()[0][0]
+
+			tt.c.Reset()
+
+			if &tt.c.GetBoard()[0][0] != first {
+				t.Error("board reallocated")
+			}
+			if n := len(tt.c.GetPlacements()); n != 0 {
+				t.Errorf("%d placements left", n)
+			}
+			if n := len(tt.c.UnplacedWords()); n != 0 {
+				t.Errorf("%d unplaced words left", n)
+			}
+			if got := tt.c.BlockRatio() * 100; got != float64(tt.blocks) {
+				t.Errorf("%v blocks left, want %d", got, tt.blocks)
+			}
+			if n := len(tt.c.FillableCells()); n != 0 {
+				t.Errorf("%d letters left", n)
+			}
+
+			// The reset crossword generates like a new one
+			if !tt.c.GeneratePuzzle([]string{"CASA", "CANE"}) {
+				t.Error("generation after Reset failed")
+			}
+		})
+	}
+}
+
+func TestIntersectionCapChoice(t *testing.T) {
+	// RAMO crosses ARCO once near the top, or CANE and TOPO lower down
+	tests := []struct {
+		name          string
+		cap           int
+		want          Position
+		intersections int
+	}{
+		{"no cap", 0, Position{X: 5, Y: 3, Dir: Vertical}, 2},
+		{"cap above", 2, Position{X: 5, Y: 3, Dir: Vertical}, 2},
+		{"cap 1", 1, Position{X: 0, Y: 0, Dir: Vertical}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCrossword(10, 10)
+			c.putWord("ARCO", 1, 0, Horizontal)
+			c.putWord("CANE", 6, 2, Horizontal)
+			c.putWord("TOPO", 8, 2, Horizontal)
+			c.opts = GenerateOptions{IntersectionCap: tt.cap, DeterministicTieBreak: true}
+
+			got, ok := c.findBestPosition("RAMO")
+			if !ok || got != tt.want {
+				t.Fatalf("findBestPosition() = %v, %v, want %v", got, ok, tt.want)
+			}
+			if n := c.canBePlaced("RAMO", got.X, got.Y, got.Dir); n != tt.intersections {
+				t.Errorf("%d intersections, want %d", n, tt.intersections)
+			}
+		})
+	}
+}
+
+func TestIntersectionCapSpread(t *testing.T) {
+	// Total crossings over several fixed orders of the same words
+	crossings := func(cap int) int {
+		total := 0
+		for seed := int64(1); seed <= 20; seed++ {
+			c := NewCrossword(12, 12)
+			words := ShuffleWords(testWords, rand.New(rand.NewSource(seed)))
+			c.GenerateWithOptions(words, GenerateOptions{IntersectionCap: cap, DeterministicTieBreak: true})
+			total += c.Report().Intersections
+		}
+		return total
+	}
+
+	uncapped, capped := crossings(0), crossings(1)
+	if capped > uncapped {
+		t.Errorf("%d crossings with the cap, more than %d without", capped, uncapped)
+	}
+}
+
+func TestRequireAll(t *testing.T) {
+	tests := []struct {
+		name       string
+		words      []string
+		requireAll bool
+		success    bool
+	}{
+		{"all fit", []string{"CASA", "CANE"}, true, true},
+		{"one too long", []string{"CASA", "ELEFANTE", "CANE"}, true, false},
+		{"one too long, partial allowed", []string{"CASA", "ELEFANTE", "CANE"}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCrossword(5, 5)
+			result := c.GenerateWithOptions(tt.words, GenerateOptions{RequireAll: tt.requireAll})
+
+			if result.Success != tt.success {
+				t.Fatalf("Success = %v, want %v (reason %q)", result.Success, tt.success, result.Reason)
+			}
+			if tt.requireAll && result.Success && len(result.Unplaced) > 0 {
+				t.Errorf("succeeded with unplaced words %v", result.Unplaced)
+			}
+			if tt.requireAll && !result.Success && len(c.GetPlacements()) > 0 {
+				t.Errorf("failed with %d words left on the board", len(c.GetPlacements()))
+			}
+		})
+	}
+}
+
+func TestGeneratePuzzleReportsSkippedWords(t *testing.T) {
+	tests := []struct {
+		name  string
+		words []string
+		want  bool
+	}{
+		{"all placed", []string{"CASA", "CANE"}, true},
+		{"unplaceable word", []string{"CASA", "ELEFANTE"}, false},
+		{"empty list", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCrossword(5, 5)
+			if got := c.GeneratePuzzle(tt.words); got != tt.want {
+				t.Errorf("GeneratePuzzle(%v) = %v, want %v", tt.words, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateFailFast(t *testing.T) {
+	tests := []struct {
+		name       string
+		words      []string
+		requireAll bool
+		placed     []string
+		success    bool
+	}{
+		{"first word skipped", []string{"ABCDEFGHIJ", "CAT", "TOE"}, false, []string{"CAT", "TOE"}, true},
+		{"first word required", []string{"ABCDEFGHIJ", "CAT", "TOE"}, true, nil, false},
+		{"nothing fits", []string{"ABCDEFGHIJ", "KLMNOPQRST"}, false, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCrossword(5, 5)
+			start := time.Now()
+			result := c.GenerateWithOptions(tt.words, GenerateOptions{RequireAll: tt.requireAll, Timeout: time.Minute})
+
+			if result.Success != tt.success {
+				t.Fatalf("Success = %v, want %v (reason %q)", result.Success, tt.success, result.Reason)
+			}
+			if !result.Success && (result.Reason == "" || len(result.Unplaced) != len(tt.words)) {
+				t.Errorf("failed with reason %q and unplaced %v", result.Reason, result.Unplaced)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("took %v", elapsed)
+			}
+
+			var placed []string
+			for _, p := range c.GetPlacements() {
+				placed = append(placed, p.Word)
+			}
+			slices.Sort(placed)
+			if !slices.Equal(placed, tt.placed) {
+				t.Errorf("placed %v, want %v", placed, tt.placed)
+			}
+		})
+	}
+}
+
+func TestInjectedRand(t *testing.T) {
+	words := []string{"GATTO", "TOPO", "ORSO", "CANE", "LUPO"}
+	counter := func(calls *int) func(n int) int {
+		return func(n int) int {
+			*calls++
+			return *calls % n
+		}
+	}
+
+	tests := []struct {
+		name string
+		seed int64
+	}{
+		{"seed 1", 1},
+		{"seed 42", 42},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The global source must not matter once Rand is injected
+			rand.Seed(tt.seed)
+
+			var calls int
+			c := NewCrossword(8, 8)
+			c.GenerateWithOptions(words, GenerateOptions{Rand: counter(&calls)})
+
+			if calls == 0 {
+				t.Fatal("injected Rand was never called")
+			}
+			if got, want := c.Encode(), "8x8:G5C1A5A1TOPO.1N1T5E1ORSO.1.1.8.LUPO.9"; got != want {
+				t.Errorf("Encode() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestDirectionBias(t *testing.T) {
+	tests := []struct {
+		name       string
+		bias       float64
+		moreAcross bool
+	}{
+		{"across", 0.9, true},
+		{"down", 0.1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			across, down := 0, 0
+			for seed := int64(1); seed <= 50; seed++ {
+				rng := rand.New(rand.NewSource(seed))
+				c := NewCrossword(12, 12)
+				c.GenerateWithOptions(testWords, GenerateOptions{Rand: rng.Intn, DirectionBias: tt.bias})
+				for _, p := range c.GetPlacements() {
+					if p.Dir == Horizontal {
+						across++
+					} else {
+						down++
+					}
+				}
+			}
+
+			if (across > down) != tt.moreAcross {
+				t.Errorf("bias %v placed %d across and %d down", tt.bias, across, down)
+			}
+		})
+	}
+}
+
+func TestSaturatedStopsEarly(t *testing.T) {
+	extra := make([]string, 500)
+	for i := range extra {
+		extra[i] = fmt.Sprintf("%c%c%c", 'A'+i%26, 'A'+i/26%26, 'Z'-i%26)
+	}
+	words := append([]string{"GATTO"}, extra...)
+
+	tests := []struct {
+		name          string
+		width, height int
+		mask          [][]bool
+		margin        int
+	}{
+		{"mask", 5, 5, blockGrid(5, 5, [2]int{2, 0}, [2]int{2, 1}, [2]int{2, 2}, [2]int{2, 3}, [2]int{2, 4}), 0},
+		{"edge margin", 7, 3, nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCrossword(tt.width, tt.height)
+			if tt.mask != nil {
+				if err := c.SetMask(tt.mask); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			start := time.Now()
+			result := c.GenerateWithOptions(words, GenerateOptions{EdgeMargin: tt.margin, DeterministicTieBreak: true})
+
+			if !result.Success || len(c.GetPlacements()) != 1 {
+				t.Fatalf("Success = %v with %d placements, want GATTO alone", result.Success, len(c.GetPlacements()))
+			}
+			if !slices.Equal(result.Unplaced, extra) {
+				t.Errorf("%d unplaced words, want the %d extra ones", len(result.Unplaced), len(extra))
+			}
+			// GATTO fills every usable cell, so the extra words are skipped
+			// together instead of being scanned one by one
+			if c.memo.scans > 5 {
+				t.Errorf("ran %d scans, want the search to stop once the grid is full", c.memo.scans)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("took %v", elapsed)
+			}
+		})
+	}
+}
+
+func TestSaturatedWithEmptyCells(t *testing.T) {
+	extra := func(n int) []string {
+		words := make([]string, 500)
+		for i := range words {
+			words[i] = strings.Repeat(string(rune('A'+i%26)), n-1) + string(rune('Z'-i/26%26))
+		}
+		return words
+	}
+
+	tests := []struct {
+		name          string
+		width, height int
+		margin        int
+		extra         []string
+	}{
+		{"edge margin leaves a cell", 9, 3, 1, extra(3)},
+		{"words longer than any run", 5, 5, 0, extra(6)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCrossword(tt.width, tt.height)
+			words := append([]string{"GATTO"}, tt.extra...)
+			result := c.GenerateWithOptions(words, GenerateOptions{EdgeMargin: tt.margin, DeterministicTieBreak: true})
+
+			if !result.Success || len(c.GetPlacements()) != 1 {
+				t.Fatalf("Success = %v with %d placements, want GATTO alone", result.Success, len(c.GetPlacements()))
+			}
+			if !slices.Equal(result.Unplaced, tt.extra) {
+				t.Errorf("%d unplaced words, want the %d extra ones", len(result.Unplaced), len(tt.extra))
+			}
+
+			empty := 0
+			for x := range c.board {
+				for y, cell := range c.board[x] {
+					if cell == ' ' && !c.inEdgeMargin(x, y) {
+						empty++
+					}
+				}
+			}
+			if empty == 0 {
+				t.Fatal("no empty cell left, want the grid not to be full")
+			}
+			// No run of empty cells is long enough for the extra words, so
+			// they are skipped together instead of being scanned one by one
+			if c.memo.scans > 5 {
+				t.Errorf("ran %d scans with %d empty cells left, want the search to stop early", c.memo.scans, empty)
+			}
+		})
+	}
+}
+
+func TestMaxWordsPerDirection(t *testing.T) {
+	tests := []struct {
+		name               string
+		maxAcross, maxDown int
+	}{
+		{"two across", 2, 0},
+		{"one down", 0, 1},
+		{"both capped", 3, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exceeded := false
+			for seed := int64(1); seed <= 10; seed++ {
+				free := NewCrossword(15, 15)
+				free.GenerateWithOptions(testWords, GenerateOptions{Rand: rand.New(rand.NewSource(seed)).Intn})
+				freeAcross, freeDown := free.CountByDirection()
+				exceeded = exceeded || tt.maxAcross > 0 && freeAcross > tt.maxAcross || tt.maxDown > 0 && freeDown > tt.maxDown
+
+				rng := rand.New(rand.NewSource(seed))
+				c := NewCrossword(15, 15)
+				c.GenerateWithOptions(testWords, GenerateOptions{Rand: rng.Intn, MaxAcross: tt.maxAcross, MaxDown: tt.maxDown})
+
+				across, down := c.CountByDirection()
+				if tt.maxAcross > 0 && across > tt.maxAcross || tt.maxDown > 0 && down > tt.maxDown {
+					t.Errorf("seed %d placed %d across and %d down", seed, across, down)
+				}
+				// The uncapped direction keeps taking words
+				if tt.maxAcross == 0 && across <= tt.maxDown || tt.maxDown == 0 && down <= tt.maxAcross {
+					t.Errorf("seed %d placed only %d across and %d down", seed, across, down)
+				}
+			}
+			if !exceeded {
+				t.Error("no seed places more words than the cap without it")
+			}
+		})
+	}
+}
+
+func TestRegenerateKeeping(t *testing.T) {
+	newWords := []string{"SOLE", "MARE", "LUNA", "STELLA", "CIELO", "NUVOLA"}
+
+	tests := []struct {
+		name string
+		keep []string
+		ok   bool
+	}{
+		{"keep the first word", []string{"PAPPAGALLO"}, true},
+		{"keep two words", []string{"PAPPAGALLO", "ELEFANTE"}, true},
+		{"keep nothing", nil, true},
+		{"keep an unplaced word", []string{"PAPPAGALLO", "GIRAFFA"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(3))
+			c := NewCrossword(15, 15)
+			c.GenerateWithOptions(testWords, GenerateOptions{Rand: rng.Intn, Order: OrderHardestFirst})
+			before := c.Clone()
+
+			result := c.RegenerateKeeping(tt.keep, newWords)
+			if !tt.ok {
+				if result.Success || result.Reason == "" {
+					t.Errorf("result = %+v, want a failure with a reason", result)
+				}
+				if !c.Equal(before) {
+					t.Error("failed regeneration changed the board")
+				}
+				return
+			}
+
+			for _, word := range tt.keep {
+				got, want := placementOf(t, c, word), placementOf(t, before, word)
+				if got.X != want.X || got.Y != want.Y || got.Dir != want.Dir {
+					t.Errorf("%s moved from (%d,%d) to (%d,%d)", word, want.X, want.Y, got.X, got.Y)
+				}
+			}
+			for _, p := range c.GetPlacements() {
+				if !slices.Contains(tt.keep, p.Word) && !slices.Contains(newWords, p.Word) {
+					t.Errorf("%s was neither kept nor new", p.Word)
+				}
+			}
+			if len(c.GetPlacements()) <= len(tt.keep) {
+				t.Error("no new word placed")
+			}
+			if !c.PlacementsConsistent() {
+				t.Error("placements don't match the board")
+			}
+		})
+	}
+}
+
+func TestBannedWords(t *testing.T) {
+	tests := []struct {
+		name    string
+		banned  []string
+		skipped []string
+	}{
+		{"none", nil, nil},
+		{"exact", []string{"LUPO"}, []string{"LUPO"}},
+		{"case and spaces", []string{" gatto ", "Orso"}, []string{"GATTO", "ORSO"}},
+		{"not in the input", []string{"GIRAFFA"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for seed := int64(1); seed <= 5; seed++ {
+				c := NewCrossword(15, 15)
+				result := c.GenerateWithOptions(testWords, GenerateOptions{Rand: rand.New(rand.NewSource(seed)).Intn, Banned: tt.banned})
+
+				if !slices.Equal(result.Banned, tt.skipped) {
+					t.Errorf("Banned = %v, want %v", result.Banned, tt.skipped)
+				}
+				for _, word := range tt.skipped {
+					if c.usedWords[word] {
+						t.Errorf("seed %d placed the banned %s", seed, word)
+					}
+					if slices.Contains(result.Unplaced, word) {
+						t.Errorf("banned %s also reported unplaced", word)
+					}
+				}
+				if placed := len(c.GetPlacements()); placed+len(result.Unplaced)+len(tt.skipped) != len(testWords) {
+					t.Errorf("%d placed, %d unplaced and %d banned of %d words", placed, len(result.Unplaced), len(tt.skipped), len(testWords))
+				}
+			}
+		})
+	}
+}
+
+func TestOrderHardestFirst(t *testing.T) {
+	// Shortest first, the worst order for the long words
+	words := slices.Clone(testWords)
+	slices.SortStableFunc(words, func(a, b string) int { return len(a) - len(b) })
+
+	hardPlaced := func(order WordOrder) int {
+		placed := 0
+		for seed := int64(1); seed <= 20; seed++ {
+			var log []GenEvent
+			c := NewCrossword(10, 10)
+			c.GenerateWithOptions(words, GenerateOptions{Rand: rand.New(rand.NewSource(seed)).Intn, Order: order, Log: &log})
+
+			first := log[0].Word
+			if order == OrderHardestFirst && first != "PAPPAGALLO" || order == OrderAsGiven && first != words[0] {
+				t.Errorf("order %v attempted %s first", order, first)
+			}
+			for _, word := range words {
+				if len(word) >= 7 && c.usedWords[word] {
+					placed++
+				}
+			}
+		}
+		return placed
+	}
+
+	tests := []struct {
+		name  string
+		order WordOrder
+	}{
+		{"as given", OrderAsGiven},
+		{"hardest first", OrderHardestFirst},
+	}
+	placed := make([]int, len(tests))
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			placed[i] = hardPlaced(tt.order)
+		})
+	}
+
+	if placed[1] <= placed[0] {
+		t.Errorf("placed %d long words hardest first, %d as given", placed[1], placed[0])
+	}
+}
+
+func TestPreferLong(t *testing.T) {
+	// Mixed set, the short words given first
+	words := []string{"RE", "TE", "ORA", "ALA", "ERA", "OCA", "ARTE", "ORSO", "TOPO", "LUPO", "CANE",
+		"PAPPAGALLO", "ELEFANTE", "CAVALLO", "GALLINA", "PECORA", "TIGRE", "LEONE"}
+
+	tests := []struct {
+		name string
+		size int
+	}{
+		{"small", 10},
+		{"medium", 13},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			average := func(preferLong bool) float64 {
+				total := 0.0
+				for seed := int64(1); seed <= 20; seed++ {
+					c := NewCrossword(tt.size, tt.size)
+					result := c.GenerateWithOptions(words, GenerateOptions{Rand: rand.New(rand.NewSource(seed)).Intn, PreferLong: preferLong})
+
+					letters := 0
+					for _, p := range c.GetPlacements() {
+						letters += p.Length
+					}
+					if want := float64(letters) / float64(len(c.GetPlacements())); result.AverageLength != want {
+						t.Errorf("AverageLength = %v, want %v", result.AverageLength, want)
+					}
+					total += result.AverageLength
+				}
+				return total / 20
+			}
+
+			with, without := average(true), average(false)
+			if with <= without {
+				t.Errorf("average length %.2f with PreferLong, %.2f without", with, without)
+			}
+		})
+	}
+}
+
+func TestNewCrosswordE(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		wantErr       bool
+	}{
+		{"zero width", 0, 5, true},
+		{"zero height", 5, 0, true},
+		{"negative", -1, 3, true},
+		{"single cell", 1, 1, false},
+		{"wide", 6, 4, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewCrosswordE(tt.width, tt.height)
+			if tt.wantErr {
+				if err == nil || c != nil {
+					t.Errorf("NewCrosswordE(%d, %d) = %v, %v, want an error", tt.width, tt.height, c, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewCrosswordE(%d, %d) error = %v", tt.width, tt.height, err)
+			}
+			if len(c.GetBoard()) != tt.height || len(c.GetBoard()[0]) != tt.width {
+				t.Errorf("board is %dx%d, want %dx%d", len(c.GetBoard()[0]), len(c.GetBoard()), tt.width, tt.height)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package utils
+
+import "math/rand"
+
+// Generator generates puzzles from its own random source and reuses its
+// scratch buffers between runs. A Generator is not safe for concurrent
+// use; give each goroutine its own, for example through a sync.Pool.
+type Generator struct {
+	Options    GenerateOptions // Options applied to every generation
+	rng        *rand.Rand
+	candidates []Position
+}
+
+// NewGenerator creates a generator seeded with the given value
+func NewGenerator(seed int64) *Generator {
+	return &Generator{rng: rand.New(rand.NewSource(seed))}
+}
+
+// Generate creates a new puzzle of the given size from the words
+func (g *Generator) Generate(width, height int, words []string) (*Crossword, GenerateResult) {
+	c := NewCrossword(width, height)
+	c.candidates = g.candidates
+
+	opts := g.Options
+	if opts.Rand == nil {
+		opts.Rand = g.rng.Intn
+	}
+	result := c.GenerateWithOptions(words, opts)
+
+	// Take the buffer and random source back so the puzzle shares nothing
+	// with later runs
+	g.candidates, c.candidates = c.candidates[:0], nil
+	c.opts = g.Options
+
+	return c, result
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestGeneratorConcurrent(t *testing.T) {
+	tests := []struct {
+		name       string
+		generators int
+		runs       int
+	}{
+		{"two", 2, 3},
+		{"eight", 8, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Sequential runs give the expected puzzle of every seed
+			want := make([][]*Crossword, tt.generators)
+			for i := range want {
+				g := NewGenerator(int64(i + 1))
+				for r := 0; r < tt.runs; r++ {
+					c, _ := g.Generate(12, 12, testWords)
+					want[i] = append(want[i], c)
+				}
+			}
+
+			got := make([][]*Crossword, tt.generators)
+			var wg sync.WaitGroup
+			for i := range got {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					g := NewGenerator(int64(i + 1))
+					for r := 0; r < tt.runs; r++ {
+						c, result := g.Generate(12, 12, testWords)
+						if !result.Success {
+							t.Errorf("generator %d run %d failed: %s", i, r, result.Reason)
+						}
+						got[i] = append(got[i], c)
+					}
+				}()
+			}
+			wg.Wait()
+
+			for i := range got {
+				for r := range got[i] {
+					if !got[i][r].Equal(want[i][r]) {
+						t.Errorf("generator %d run %d differs from its sequential run", i, r)
+					}
+				}
+			}
+		})
+	}
+}
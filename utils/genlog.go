@@ -0,0 +1,46 @@
+package utils
+
+// GenAction is a decision taken by the generation search
+type GenAction int
+
+const (
+	ActionPlace  GenAction = 0 // Word written to the board
+	ActionRemove GenAction = 1 // Word taken back off the board while backtracking
+	ActionSkip   GenAction = 2 // Word left out, its position is unset
+)
+
+// GenEvent is one entry of the generation log
+type GenEvent struct {
+	Action GenAction
+	Word   string
+	X, Y   int
+	Dir    Direction
+}
+
+// logEvent appends to the generation log when one was requested
+func (c *Crossword) logEvent(action GenAction, word string, pos *Position) {
+	if c.opts.Log == nil {
+		return
+	}
+
+	event := GenEvent{Action: action, Word: word}
+	if pos != nil {
+		event.X, event.Y, event.Dir = pos.X, pos.Y, pos.Dir
+	}
+	*c.opts.Log = append(*c.opts.Log, event)
+}
+
+// Replay applies the place and remove events of a generation log to the
+// crossword, reproducing the grid of the logged generation when started
+// from the same board
+func (c *Crossword) Replay(events []GenEvent) {
+	for _, e := range events {
+		switch e.Action {
+		case ActionPlace:
+			c.putWord(e.Word, e.X, e.Y, e.Dir)
+		case ActionRemove:
+			c.removeWord(e.Word, e.X, e.Y, e.Dir)
+		}
+	}
+	c.AssignNumbers()
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestGenerationLogReplay(t *testing.T) {
+	tests := []struct {
+		name  string
+		size  int
+		words []string
+		opts  GenerateOptions
+		undo  bool // whether the search has to take words back
+	}{
+		{"plain", 12, testWords, GenerateOptions{}, false},
+		{"fast scan", 12, testWords, GenerateOptions{FastScan: true}, false},
+		{"backtracking", 8, testWords, GenerateOptions{RequireAll: true, Timeout: 200 * time.Millisecond}, true},
+		{"word cap", 12, testWords, GenerateOptions{MaxWords: 4}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for seed := int64(1); seed <= 3; seed++ {
+				var log []GenEvent
+				opts := tt.opts
+				opts.Rand = rand.New(rand.NewSource(seed)).Intn
+				opts.Log = &log
+
+				c := NewCrossword(tt.size, tt.size)
+				c.GenerateWithOptions(tt.words, opts)
+
+				// Every placed word is placed once more than it is removed
+				net, removed := 0, 0
+				for _, e := range log {
+					switch e.Action {
+					case ActionPlace:
+						net++
+					case ActionRemove:
+						net--
+						removed++
+					}
+				}
+				if tt.undo && removed == 0 {
+					t.Errorf("seed %d: no word taken back", seed)
+				}
+				if net != len(c.GetPlacements()) {
+					t.Errorf("seed %d: log nets %d placements, grid holds %d", seed, net, len(c.GetPlacements()))
+				}
+
+				replayed := NewCrossword(tt.size, tt.size)
+				replayed.Replay(log)
+				if !replayed.Equal(c) {
+					diff, _ := DiffGrids(replayed, c)
+					t.Errorf("seed %d: replayed grid differs at %v", seed, diff)
+				}
+			}
+		})
+	}
+}
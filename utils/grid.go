@@ -0,0 +1,188 @@
+package utils
+
+import "fmt"
+
+// CrosswordFromGrid builds a crossword from plain rows of letters, where
+// '#' marks a block and ' ' or '.' an empty cell. Placements, word ids and
+// counts are inferred from every maximal run of two or more letters.
+func CrosswordFromGrid(rows []string) (*Crossword, error) {
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("grid has no rows")
+	}
+
+	width := len([]rune(rows[0]))
+	if width == 0 {
+		return nil, fmt.Errorf("grid has no columns")
+	}
+
+	c := NewCrossword(width, len(rows))
+	for x, row := range rows {
+		cells := []rune(row)
+		if len(cells) != width {
+			return nil, fmt.Errorf("row %d has %d cells, expected %d", x, len(cells), width)
+		}
+
+		for y, cell := range cells {
+			switch cell {
+			case '#':
+				c.board[x][y] = '*'
+			case ' ', '.':
+				c.board[x][y] = ' '
+			default:
+				c.board[x][y] = cell
+			}
+		}
+	}
+
+	for _, entry := range c.Entries() {
+		c.registerPlacement(entry)
+	}
+	c.AssignNumbers()
+
+	return c, nil
+}
+
+// registerPlacement records a word already present on the board, updating
+// the word-id arrays without touching any cell
+func (c *Crossword) registerPlacement(p WordPlacement) {
+	value := 0
+	if p.Dir == Horizontal {
+		c.hCount++
+		value = c.hCount
+	} else {
+		c.vCount++
+		value = c.vCount
+	}
+
+	for i := 0; i < p.Length; i++ {
+		x, y := p.cell(i)
+		if p.Dir == Horizontal {
+			c.hWords[x][y] = value
+		} else {
+			c.vWords[x][y] = value
+		}
+	}
+
+	c.usedWords[p.Word] = true
+	c.placements = append(c.placements, p)
+}
+
+// Trim shrinks the puzzle in place to the bounding box of its non-empty
+// cells, dropping fully empty border rows and columns. Placement
+// coordinates are shifted to match. An empty board is left unchanged.
+func (c *Crossword) Trim() {
+	top, bottom, left, right := c.height, -1, c.width, -1
+	for x := range c.board {
+		for y, cell := range c.board[x] {
+			if cell != ' ' {
+				top, bottom = min(top, x), max(bottom, x)
+				left, right = min(left, y), max(right, y)
+			}
+		}
+	}
+	if bottom < 0 {
+		return
+	}
+
+	c.board = c.board[top : bottom+1]
+	c.hWords = c.hWords[top : bottom+1]
+	c.vWords = c.vWords[top : bottom+1]
+	for x := range c.board {
+		c.board[x] = c.board[x][left : right+1]
+		c.hWords[x] = c.hWords[x][left : right+1]
+		c.vWords[x] = c.vWords[x][left : right+1]
+	}
+
+	if c.template != nil {
+		c.template = c.template[top : bottom+1]
+		for x := range c.template {
+			c.template[x] = c.template[x][left : right+1]
+		}
+	}
+	if c.mask != nil {
+		c.mask = c.mask[top : bottom+1]
+		for x := range c.mask {
+			c.mask[x] = c.mask[x][left : right+1]
+		}
+	}
+
+	for i := range c.placements {
+		c.placements[i].X -= top
+		c.placements[i].Y -= left
+	}
+
+	if len(c.rebus) > 0 {
+		shifted := make(map[[2]int]string, len(c.rebus))
+		for cell, letters := range c.rebus {
+			shifted[[2]int{cell[0] - top, cell[1] - left}] = letters
+		}
+		c.rebus = shifted
+	}
+
+	c.height = bottom - top + 1
+	c.width = right - left + 1
+	c.frontier = nil
+}
+
+// transposed returns a copy mirrored along the main diagonal, turning
+// across words into down words while keeping every word readable
+func (c *Crossword) transposed() *Crossword {
+	t := NewCrossword(c.height, c.width)
+	for x := range c.board {
+		for y, cell := range c.board[x] {
+			t.board[y][x] = cell
+			t.hWords[y][x] = c.vWords[x][y]
+			t.vWords[y][x] = c.hWords[x][y]
+		}
+	}
+	if c.mask != nil {
+		t.mask = make([][]bool, c.width)
+		for y := range t.mask {
+			t.mask[y] = make([]bool, c.height)
+			for x := range c.mask {
+				t.mask[y][x] = c.mask[x][y]
+			}
+		}
+	}
+
+	for word := range c.usedWords {
+		t.usedWords[word] = true
+	}
+	for cell, letters := range c.rebus {
+		if t.rebus == nil {
+			t.rebus = make(map[[2]int]string, len(c.rebus))
+		}
+		t.rebus[[2]int{cell[1], cell[0]}] = letters
+	}
+	for _, p := range c.placements {
+		p.X, p.Y = p.Y, p.X
+		p.Dir = 1 - p.Dir
+		t.placements = append(t.placements, p)
+	}
+	t.hCount, t.vCount = c.vCount, c.hCount
+	t.meta = c.meta
+	t.AssignNumbers()
+
+	return t
+}
+
+// Skeleton returns the structure of the grid for external solvers: which
+// cells hold letters, and the numbered entry slots with their words left
+// empty
+func (c *Crossword) Skeleton() (fillable [][]bool, slots []WordPlacement) {
+	fillable = make([][]bool, c.height)
+	for x := range c.board {
+		fillable[x] = make([]bool, c.width)
+		for y, cell := range c.board[x] {
+			fillable[x][y] = isLetter(cell)
+		}
+	}
+
+	slots = c.Entries()
+	numberInReadingOrder(slots, nil)
+	for i := range slots {
+		slots[i].Word = ""
+	}
+
+	return fillable, slots
+}
@@ -0,0 +1,178 @@
+package utils
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestCrosswordFromGrid(t *testing.T) {
+	rows := []string{
+		"CASA#",
+		"A#..#",
+		"NAVE.",
+		"E....",
+	}
+	c, err := CrosswordFromGrid(rows)
+	if err != nil {
+		t.Fatalf("CrosswordFromGrid() error = %v", err)
+	}
+
+	want := []WordPlacement{
+		{X: 0, Y: 0, Dir: Horizontal, Length: 4, Word: "CASA", Number: 1},
+		{X: 2, Y: 0, Dir: Horizontal, Length: 4, Word: "NAVE", Number: 2},
+		{X: 0, Y: 0, Dir: Vertical, Length: 4, Word: "CANE", Number: 1},
+	}
+	if got := c.GetPlacements(); !slices.Equal(got, want) {
+		t.Errorf("placements = %v, want %v", got, want)
+	}
+	if across, down := c.CountByDirection(); across != 2 || down != 1 {
+		t.Errorf("CountByDirection() = %d, %d, want 2, 1", across, down)
+	}
+	if c.GetBoard()[0][4] != '*' || c.GetBoard()[1][2] != ' ' {
+		t.Error("blocks or empty cells not converted")
+	}
+	if got := c.CrossingPlacements(want[2]); len(got) != 2 {
+		t.Errorf("CANE crosses %v, want CASA and NAVE", got)
+	}
+}
+
+func TestCrosswordFromGridInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		rows []string
+	}{
+		{"no rows", nil},
+		{"no columns", []string{""}},
+		{"ragged", []string{"CASA", "CAS"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CrosswordFromGrid(tt.rows); err == nil {
+				t.Errorf("CrosswordFromGrid(%q) succeeded, want an error", tt.rows)
+			}
+		})
+	}
+}
+
+func TestTrim(t *testing.T) {
+	tests := []struct {
+		name  string
+		words []string
+		seed  int64
+	}{
+		{"two words", []string{"CASA", "CANE"}, 1},
+		{"animals", []string{"GATTO", "TOPO", "ORSO", "CANE", "LUPO"}, 2},
+		{"long words", []string{"PAPPAGALLO", "ELEFANTE", "GALLINA"}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCrossword(25, 25)
+			rng := rand.New(rand.NewSource(tt.seed))
+			c.GenerateWithOptions(tt.words, GenerateOptions{Rand: rng.Intn})
+			placed := len(c.GetPlacements())
+			if placed == 0 {
+				t.Fatal("nothing placed")
+			}
+
+			c.Trim()
+
+			if c.width >= 25 || c.height >= 25 {
+				t.Errorf("trimmed to %dx%d, want smaller than 25x25", c.width, c.height)
+			}
+			if len(c.board) != c.height || len(c.board[0]) != c.width {
+				t.Errorf("board is %dx%d, dimensions say %dx%d", len(c.board[0]), len(c.board), c.width, c.height)
+			}
+			if len(c.GetPlacements()) != placed {
+				t.Errorf("%d placements after trim, want %d", len(c.GetPlacements()), placed)
+			}
+			if !c.PlacementsConsistent() {
+				t.Error("placements no longer match the board letters")
+			}
+
+			// Every border row and column now holds something
+			for _, cells := range [][]rune{c.board[0], c.board[c.height-1], column(c.board, 0), column(c.board, c.width-1)} {
+				if !slices.ContainsFunc(cells, func(r rune) bool { return r != ' ' }) {
+					t.Errorf("border %q is empty", string(cells))
+				}
+			}
+		})
+	}
+}
+
+// column returns the cells of column y, top to bottom
+func column(board [][]rune, y int) []rune {
+	var cells []rune
+	for x := range board {
+		cells = append(cells, board[x][y])
+	}
+	return cells
+}
+
+func TestSkeleton(t *testing.T) {
+	generated := func() *Crossword {
+		rng := rand.New(rand.NewSource(5))
+		c := NewCrossword(12, 12)
+		c.GenerateWithOptions(testWords, GenerateOptions{Rand: rng.Intn})
+		return c
+	}
+
+	tests := []struct {
+		name   string
+		puzzle func() *Crossword
+		slots  []WordPlacement // nil to match the numbered placements
+	}{
+		{"crossing", crossingPuzzle, []WordPlacement{
+			{X: 1, Y: 1, Dir: Horizontal, Length: 4, Number: 1},
+			{X: 1, Y: 1, Dir: Vertical, Length: 4, Number: 1},
+		}},
+		{"accidental words", accidentalPuzzle, []WordPlacement{
+			{X: 1, Y: 1, Dir: Horizontal, Length: 4, Number: 1},
+			{X: 1, Y: 1, Dir: Vertical, Length: 4, Number: 1},
+			{X: 1, Y: 2, Dir: Vertical, Length: 2, Number: 2},
+			{X: 2, Y: 1, Dir: Horizontal, Length: 2, Number: 3},
+		}},
+		{"generated", generated, nil},
+	}
+
+	bySlot := func(a, b WordPlacement) int {
+		if a.X != b.X {
+			return a.X - b.X
+		}
+		if a.Y != b.Y {
+			return a.Y - b.Y
+		}
+		return int(a.Dir) - int(b.Dir)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.puzzle()
+			fillable, slots := c.Skeleton()
+
+			for x := range c.board {
+				for y, cell := range c.board[x] {
+					if fillable[x][y] != isLetter(cell) {
+						t.Errorf("fillable(%d,%d) = %v for %q", x, y, fillable[x][y], cell)
+					}
+				}
+			}
+
+			want := tt.slots
+			if want == nil {
+				c.AssignNumbers()
+				for _, p := range c.GetPlacements() {
+					p.Word, p.Clue = "", ""
+					want = append(want, p)
+				}
+			}
+			slices.SortFunc(slots, bySlot)
+			slices.SortFunc(want, bySlot)
+			if !slices.Equal(slots, want) {
+				t.Errorf("Skeleton() slots = %+v, want %+v", slots, want)
+			}
+		})
+	}
+}
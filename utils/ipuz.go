@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// ipuzDocument is the subset of the .ipuz crossword format written by ExportIPuz
+type ipuzDocument struct {
+	Version    string           `json:"version"`
+	Kind       []string         `json:"kind"`
+	Title      string           `json:"title,omitempty"`
+	Author     string           `json:"author,omitempty"`
+	Date       string           `json:"date,omitempty"`
+	Copyright  string           `json:"copyright,omitempty"`
+	Dimensions ipuzDimensions   `json:"dimensions"`
+	Puzzle     [][]any          `json:"puzzle"`
+	Solution   [][]any          `json:"solution"`
+	Clues      map[string][]any `json:"clues"`
+}
+
+type ipuzDimensions struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// ipuzBlock is the default block cell of the format
+const ipuzBlock = "#"
+
+// ExportIPuz encodes the puzzle as an .ipuz document. Blocks are written as
+// "#" and cells outside any word as null. Clue text is looked up in clues
+// by number, falling back to the placement's own clue.
+func ExportIPuz(puzzle *Crossword, clues map[int]string) ([]byte, error) {
+	meta := puzzle.Meta()
+	doc := ipuzDocument{
+		Version:    "http://ipuz.org/v2",
+		Kind:       []string{"http://ipuz.org/crossword#1"},
+		Title:      meta.Title,
+		Author:     meta.Author,
+		Date:       meta.Date,
+		Copyright:  meta.Copyright,
+		Dimensions: ipuzDimensions{Width: puzzle.width, Height: puzzle.height},
+		Clues:      map[string][]any{"Across": {}, "Down": {}},
+	}
+
+	numbers := make(map[[2]int]int)
+	placements := append([]WordPlacement(nil), puzzle.GetPlacements()...)
+	sort.SliceStable(placements, func(i, j int) bool {
+		return placements[i].Number < placements[j].Number
+	})
+	for _, p := range placements {
+		numbers[[2]int{p.X, p.Y}] = p.Number
+
+		text, ok := clues[p.Number]
+		if !ok {
+			text = p.Clue
+		}
+		section := "Across"
+		if p.Dir == Vertical {
+			section = "Down"
+		}
+		doc.Clues[section] = append(doc.Clues[section], []any{p.Number, text})
+	}
+
+	doc.Puzzle = make([][]any, puzzle.height)
+	doc.Solution = make([][]any, puzzle.height)
+	for x, row := range puzzle.board {
+		doc.Puzzle[x] = make([]any, puzzle.width)
+		doc.Solution[x] = make([]any, puzzle.width)
+		for y, cell := range row {
+			switch {
+			case cell == '*':
+				doc.Puzzle[x][y] = ipuzBlock
+				doc.Solution[x][y] = ipuzBlock
+			case isLetter(cell):
+				doc.Puzzle[x][y] = numbers[[2]int{x, y}]
+				doc.Solution[x][y] = puzzle.cellText(x, y)
+			}
+		}
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestExportIPuz(t *testing.T) {
+	withMeta := crossingPuzzle()
+	withMeta.SetMeta(Meta{Title: "Animali", Author: "Flo"})
+
+	tests := []struct {
+		name   string
+		puzzle *Crossword
+		clues  map[int]string
+		across [][]any
+		down   [][]any
+	}{
+		{"crossing", withMeta, map[int]string{1: "Abitazione"},
+			[][]any{{1.0, "Abitazione"}}, [][]any{{1.0, "Abitazione"}}},
+		{"placement clues", func() *Crossword {
+			c := crossingPuzzle()
+			c.SetClues(map[string]string{"CASA": "Abitazione", "CANE": "Abbaia"})
+			return c
+		}(), nil, [][]any{{1.0, "Abitazione"}}, [][]any{{1.0, "Abbaia"}}},
+		{"rebus", rebusPuzzle(t), nil, [][]any{{1.0, ""}}, [][]any{{2.0, ""}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := ExportIPuz(tt.puzzle, tt.clues)
+			if err != nil {
+				t.Fatalf("ExportIPuz() error = %v", err)
+			}
+
+			var doc struct {
+				Version    string             `json:"version"`
+				Kind       []string           `json:"kind"`
+				Title      string             `json:"title"`
+				Dimensions map[string]int     `json:"dimensions"`
+				Puzzle     [][]any            `json:"puzzle"`
+				Solution   [][]any            `json:"solution"`
+				Clues      map[string][][]any `json:"clues"`
+			}
+			if err := json.Unmarshal(data, &doc); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+
+			if doc.Version != "http://ipuz.org/v2" || !reflect.DeepEqual(doc.Kind, []string{"http://ipuz.org/crossword#1"}) {
+				t.Errorf("version %q and kind %v", doc.Version, doc.Kind)
+			}
+			if doc.Title != tt.puzzle.Meta().Title {
+				t.Errorf("title %q, want %q", doc.Title, tt.puzzle.Meta().Title)
+			}
+			c := tt.puzzle
+			if doc.Dimensions["width"] != c.width || doc.Dimensions["height"] != c.height {
+				t.Fatalf("dimensions %v, want %dx%d", doc.Dimensions, c.width, c.height)
+			}
+			if len(doc.Puzzle) != c.height || len(doc.Solution) != c.height {
+				t.Fatalf("%d puzzle and %d solution rows, want %d", len(doc.Puzzle), len(doc.Solution), c.height)
+			}
+
+			numbers := make(map[[2]int]float64)
+			for _, p := range c.GetPlacements() {
+				numbers[[2]int{p.X, p.Y}] = float64(p.Number)
+			}
+			for x := range c.board {
+				for y, cell := range c.board[x] {
+					var puzzle, solution any
+					switch {
+					case cell == '*':
+						puzzle, solution = "#", "#"
+					case isLetter(cell):
+						puzzle, solution = numbers[[2]int{x, y}], c.cellText(x, y)
+					}
+					if doc.Puzzle[x][y] != puzzle || doc.Solution[x][y] != solution {
+						t.Errorf("cell (%d,%d) is %v / %v, want %v / %v", x, y, doc.Puzzle[x][y], doc.Solution[x][y], puzzle, solution)
+					}
+				}
+			}
+
+			if !reflect.DeepEqual(doc.Clues["Across"], tt.across) || !reflect.DeepEqual(doc.Clues["Down"], tt.down) {
+				t.Errorf("clues %v, want Across %v and Down %v", doc.Clues, tt.across, tt.down)
+			}
+		})
+	}
+}
@@ -1,7 +1,18 @@
 package utils
 
-import "math/rand"
+import (
+	"fmt"
+	"math/rand"
+)
 
-func RandInt(i int, i2 int) int {
-	return rand.Intn(i2-i) + i
+// RandInt returns a random number in [lo, hi) drawn from rng. It returns lo
+// when lo == hi and panics when lo > hi.
+func RandInt(lo, hi int, rng *rand.Rand) int {
+	if lo > hi {
+		panic(fmt.Sprintf("utils.RandInt: invalid range [%d, %d)", lo, hi))
+	}
+	if lo == hi {
+		return lo
+	}
+	return rng.Intn(hi-lo) + lo
 }
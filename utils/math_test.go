@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRandInt(t *testing.T) {
+	tests := []struct {
+		name   string
+		lo, hi int
+	}{
+		{"empty range", 3, 3},
+		{"single value", 4, 5},
+		{"from zero", 0, 10},
+		{"negative", -5, 5},
+		{"wide", 100, 1000},
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen := make(map[int]bool)
+			for i := 0; i < 1000; i++ {
+				n := RandInt(tt.lo, tt.hi, rng)
+				if tt.lo == tt.hi {
+					if n != tt.lo {
+						t.Fatalf("RandInt(%d, %d) = %d, want %d", tt.lo, tt.hi, n, tt.lo)
+					}
+					continue
+				}
+				if n < tt.lo || n >= tt.hi {
+					t.Fatalf("RandInt(%d, %d) = %d, out of range", tt.lo, tt.hi, n)
+				}
+				seen[n] = true
+			}
+			if tt.hi-tt.lo <= 10 && len(seen) != tt.hi-tt.lo {
+				t.Errorf("drew %d distinct values, want all %d", len(seen), tt.hi-tt.lo)
+			}
+		})
+	}
+}
+
+func TestRandIntPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("RandInt(5, 4) did not panic")
+		}
+	}()
+	RandInt(5, 4, rand.New(rand.NewSource(1)))
+}
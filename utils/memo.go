@@ -0,0 +1,93 @@
+package utils
+
+// openMemo caches, while a scan runs, how many cells in a row are open
+// from each cell, as the board can't change in the middle of one. An entry
+// is valid when its stamp matches the current scan, so starting a scan
+// invalidates every entry at once.
+type openMemo struct {
+	runs   []int
+	stamps []uint32
+	scan   uint32 // stamp of the running scan, 0 outside scans
+	scans  uint32 // scans started so far
+	off    bool   // bypasses the memo, for comparisons in tests
+}
+
+// beginScan starts memoizing open runs for a new scan
+func (c *Crossword) beginScan() {
+	size := 2 * c.width * c.height
+	if len(c.memo.runs) != size {
+		c.memo.runs = make([]int, size)
+		c.memo.stamps = make([]uint32, size)
+	}
+
+	c.memo.scans++
+	if c.memo.scans == 0 {
+		// The counter wrapped, old stamps could look current
+		clear(c.memo.stamps)
+		c.memo.scans = 1
+	}
+	if !c.memo.off {
+		c.memo.scan = c.memo.scans
+	}
+}
+
+// endScan stops memoizing, the board may change again
+func (c *Crossword) endScan() {
+	c.memo.scan = 0
+}
+
+// runOpen reports whether length cells from (x,y) onward are open to a
+// word running in the given direction. During a scan the open run from
+// each cell is computed once, so every position is checked in one step.
+func (c *Crossword) runOpen(x, y, length int, dir Direction) bool {
+	if c.memo.scan == 0 || !c.isValidPosition(x, y) {
+		p := WordPlacement{X: x, Y: y, Dir: dir, Length: length}
+		for i := 0; i < length; i++ {
+			if x1, y1 := p.cell(i); !c.cellOpen(x1, y1, dir) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if k := c.startIndex(x, y, dir); c.memo.stamps[k] == c.memo.scan {
+		return c.memo.runs[k] >= length
+	}
+	return c.openRun(x, y, dir) >= length
+}
+
+// openRun returns the number of open cells in a row from (x,y) onward,
+// filling the memo for every cell it walks past
+func (c *Crossword) openRun(x, y int, dir Direction) int {
+	dx, dy := 1, 0
+	if dir == Horizontal {
+		dx, dy = 0, 1
+	}
+
+	// Walk to the first closed cell, or to one whose run is known
+	n, tail := 0, 0
+	for x1, y1 := x, y; ; x1, y1 = x1+dx, y1+dy {
+		if x1 >= c.height || y1 >= c.width {
+			break
+		}
+		k := c.startIndex(x1, y1, dir)
+		if c.memo.stamps[k] == c.memo.scan {
+			tail = c.memo.runs[k]
+			break
+		}
+		if !c.cellOpen(x1, y1, dir) {
+			c.memo.stamps[k] = c.memo.scan
+			c.memo.runs[k] = 0
+			break
+		}
+		n++
+	}
+
+	// Each walked cell starts a run one longer than the next
+	for i := n - 1; i >= 0; i-- {
+		k := c.startIndex(x+i*dx, y+i*dy, dir)
+		c.memo.stamps[k] = c.memo.scan
+		c.memo.runs[k] = tail + n - i
+	}
+	return c.memo.runs[c.startIndex(x, y, dir)]
+}
@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestScanMemoSameDecisions(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+		opts GenerateOptions
+	}{
+		{"row-major", 12, GenerateOptions{}},
+		{"spiral", 12, GenerateOptions{ScanOrder: ScanSpiral}},
+		{"fast", 15, GenerateOptions{FastScan: true}},
+		{"tie-breaks", 15, GenerateOptions{AvoidDeadEnds: true, PreferOpenSpace: true, EndpointBias: true}},
+		{"require all", 10, GenerateOptions{RequireAll: true}},
+	}
+
+	words := dataWords(t)[:120]
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for seed := int64(1); seed <= 3; seed++ {
+				generate := func(off bool) *Crossword {
+					c := NewCrossword(tt.size, tt.size)
+					c.memo.off = off
+					opts := tt.opts
+					opts.Rand = rand.New(rand.NewSource(seed)).Intn
+					c.GenerateWithOptions(words, opts)
+					return c
+				}
+
+				memo, plain := generate(false), generate(true)
+				if !slices.Equal(memo.GetPlacements(), plain.GetPlacements()) {
+					t.Errorf("seed %d: placements differ with the memo\n%v\n%v", seed, memo.GetPlacements(), plain.GetPlacements())
+				}
+				if !memo.Equal(plain) {
+					t.Errorf("seed %d: boards differ with the memo", seed)
+				}
+			}
+		})
+	}
+}
+
+func TestRunOpen(t *testing.T) {
+	masked := func() *Crossword {
+		c := crossingPuzzle()
+		mask := blockGrid(6, 6)
+		for x := range mask {
+			for y := range mask[x] {
+				mask[x][y] = x+y < 9
+			}
+		}
+		if err := c.SetMask(mask); err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	margin := func() *Crossword {
+		c := crossingPuzzle()
+		c.opts.EdgeMargin = 1
+		return c
+	}
+
+	tests := []struct {
+		name   string
+		puzzle func() *Crossword
+	}{
+		{"crossing", crossingPuzzle},
+		{"mask", masked},
+		{"edge margin", margin},
+		{"empty", func() *Crossword { return NewCrossword(5, 7) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.puzzle()
+			c.beginScan()
+			defer c.endScan()
+
+			for _, dir := range bothDirections {
+				for x := 0; x < c.height; x++ {
+					for y := 0; y < c.width; y++ {
+						for length := 1; length <= 7; length++ {
+							p := WordPlacement{X: x, Y: y, Dir: dir, Length: length}
+							want := true
+							for i := 0; i < length; i++ {
+								if x1, y1 := p.cell(i); !c.cellOpen(x1, y1, dir) {
+									want = false
+								}
+							}
+							if got := c.runOpen(x, y, length, dir); got != want {
+								t.Fatalf("runOpen(%d, %d, %d, %v) = %v, want %v", x, y, length, dir, got, want)
+							}
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkScanMemo scans a filled board for every word, with cellOpen
+// answered from the memo and computed for every position
+func BenchmarkScanMemo(b *testing.B) {
+	words := dataWords(b)[:300]
+	c := NewCrossword(40, 40)
+	c.GenerateWithOptions(words[:150], GenerateOptions{Rand: rand.New(rand.NewSource(1)).Intn})
+	c.opts.DeterministicTieBreak = true
+
+	for _, tt := range []struct {
+		name string
+		off  bool
+	}{{"memo", false}, {"plain", true}} {
+		b.Run(tt.name, func(b *testing.B) {
+			c.memo.off = tt.off
+			for i := 0; i < b.N; i++ {
+				for _, word := range words[150:] {
+					c.findBestPosition(word)
+				}
+			}
+		})
+	}
+}
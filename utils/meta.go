@@ -0,0 +1,19 @@
+package utils
+
+// Meta holds the publication details of a puzzle
+type Meta struct {
+	Title     string `json:"title,omitempty"`
+	Author    string `json:"author,omitempty"`
+	Date      string `json:"date,omitempty"` // Publication date, free form
+	Copyright string `json:"copyright,omitempty"`
+}
+
+// SetMeta replaces the puzzle metadata
+func (c *Crossword) SetMeta(meta Meta) {
+	c.meta = meta
+}
+
+// Meta returns the puzzle metadata
+func (c *Crossword) Meta() Meta {
+	return c.meta
+}
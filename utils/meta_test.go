@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMetaJSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		meta Meta
+		json string
+	}{
+		{"empty", Meta{}, `{}`},
+		{"title only", Meta{Title: "Animali"}, `{"title":"Animali"}`},
+		{"full", Meta{Title: "Animali", Author: "Flo", Date: "2024-05-01", Copyright: "© 2024"},
+			`{"title":"Animali","author":"Flo","date":"2024-05-01","copyright":"© 2024"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := crossingPuzzle()
+			c.SetMeta(tt.meta)
+
+			data, err := json.Marshal(c.Meta())
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(data) != tt.json {
+				t.Errorf("Marshal() = %s, want %s", data, tt.json)
+			}
+
+			var decoded Meta
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if decoded != tt.meta {
+				t.Errorf("round trip gave %+v, want %+v", decoded, tt.meta)
+			}
+
+			if got := c.Clone().Meta(); got != tt.meta {
+				t.Errorf("clone has %+v, want %+v", got, tt.meta)
+			}
+		})
+	}
+}
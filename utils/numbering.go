@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"cmp"
+	"slices"
+)
+
+// AssignNumbers numbers the placements in reading order, words starting
+// in the same cell share a number
+func (c *Crossword) AssignNumbers() {
+	c.numbering = numberInReadingOrder(c.placements, c.numbering[:0])
+}
+
+// numberInReadingOrder sets the Number of each placement from the reading
+// order of its start cell. The distinct start cells are collected, sorted,
+// into starts, which is returned for reuse.
+func numberInReadingOrder(placements []WordPlacement, starts [][2]int) [][2]int {
+	for _, p := range placements {
+		starts = append(starts, [2]int{p.X, p.Y})
+	}
+
+	// Reading order: top to bottom, then left to right
+	slices.SortFunc(starts, compareReading)
+	starts = slices.Compact(starts)
+
+	for i := range placements {
+		p := &placements[i]
+		n, _ := slices.BinarySearchFunc(starts, [2]int{p.X, p.Y}, compareReading)
+		p.Number = n + 1
+	}
+	return starts
+}
+
+// compareReading orders cells top to bottom, then left to right
+func compareReading(a, b [2]int) int {
+	if a[0] != b[0] {
+		return cmp.Compare(a[0], b[0])
+	}
+	return cmp.Compare(a[1], b[1])
+}
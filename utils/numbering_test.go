@@ -0,0 +1,28 @@
+package utils
+
+import "testing"
+
+func TestAssignNumbers(t *testing.T) {
+	c := crossingPuzzle()
+	c.putWord("SOLE", 1, 3, Vertical)
+	c.AssignNumbers()
+
+	tests := []struct {
+		word   string
+		number int
+	}{
+		{"CASA", 1},
+		{"CANE", 1},
+		{"SOLE", 2},
+	}
+
+	numbers := make(map[string]int)
+	for _, p := range c.GetPlacements() {
+		numbers[p.Word] = p.Number
+	}
+	for _, tt := range tests {
+		if got := numbers[tt.word]; got != tt.number {
+			t.Errorf("%s numbered %d, want %d", tt.word, got, tt.number)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package utils
+
+import "time"
+
+// ScanOrder is the order in which candidate start cells are examined
+type ScanOrder int
+
+const (
+	ScanRowMajor ScanOrder = 0 // Top to bottom, left to right
+	ScanSpiral   ScanOrder = 1 // Expanding spiral from the center, ties go to the most central cell
+)
+
+// WordOrder is the order in which input words are attempted
+type WordOrder int
+
+const (
+	OrderAsGiven      WordOrder = 0 // The order of the input slice
+	OrderHardestFirst WordOrder = 1 // Longest first, then rarest by Frequency
+)
+
+// GenerateOptions holds optional constraints for puzzle generation
+type GenerateOptions struct {
+	MinFillRatio  float64       // Minimum ratio of placed to input words, 0 disables the check
+	EdgeMargin    int           // Number of outer cell rings no word may occupy
+	MaxBlockRatio float64       // Maximum ratio of block cells to all cells, 0 disables the check
+	RequireAll    bool          // Fail unless every input word is placed
+	MaxAcross     int           // Maximum number of across words, 0 means no limit
+	MaxDown       int           // Maximum number of down words, 0 means no limit
+	MaxWords      int           // Maximum number of words placed, 0 means no limit
+	Timeout       time.Duration // Time budget for the search, 0 means one minute
+
+	// IntersectionCap stops crossings beyond this count from making a
+	// position more attractive, spreading words out. 0 disables the cap.
+	IntersectionCap int
+
+	// DeterministicTieBreak picks the first of equally good positions in
+	// scan order, horizontal before vertical, instead of a random one
+	DeterministicTieBreak bool
+
+	ScanOrder ScanOrder // Order in which start cells are examined
+
+	// FastScan only tries the starts crossing a letter already on the
+	// board, so after the first word every word crosses another and words
+	// that would stand apart are skipped
+	FastScan bool
+
+	// DirectionBias is the chance that a random tie between across and down
+	// positions goes to across: near 0 favors down, 1 always across. Zero
+	// leaves ties unbiased. Ignored by deterministic tie-breaks.
+	DirectionBias float64
+
+	// AvoidDeadEnds prefers, among equally good positions, those sealing
+	// off the fewest empty cells from any future word
+	AvoidDeadEnds bool
+
+	// PreferOpenSpace prefers, among equally good positions, those leaving
+	// the most empty cells next to the word for later crossings
+	PreferOpenSpace bool
+
+	// EndpointBias prefers, among equally good positions, those crossing an
+	// existing word within two cells of one of its ends, then those growing
+	// the bounding box of the placed words the least, for compact grids
+	EndpointBias bool
+
+	Order WordOrder // Order in which the words are attempted
+
+	// Frequency maps words to how common they are, for OrderHardestFirst.
+	// Missing words count as 0, the rarest.
+	Frequency map[string]int
+
+	// PreferLong attempts the longest words first and places short words
+	// only where they cross at least two letters, favoring fewer, longer
+	// entries over many short ones
+	PreferLong bool
+
+	// Banned words are dropped from the input before placement, compared
+	// case-insensitively
+	Banned []string
+
+	// Log, when not nil, receives every place, remove and skip decision
+	// of the search in order, for replaying a generation with Replay
+	Log *[]GenEvent
+
+	// Rand returns a random number in [0, n). Injecting a deterministic
+	// function keeps output stable across Go versions. nil uses math/rand.
+	Rand func(n int) int
+}
+
+// GenerateResult describes the outcome of a puzzle generation
+type GenerateResult struct {
+	Success       bool     // Whether generation met every requested constraint
+	Placed        int      // Number of words placed on the board
+	Unplaced      []string // Words that could not be placed, overwritten by the next generation
+	Banned        []string // Input words skipped because they are banned
+	FillRatio     float64  // Ratio of placed to distinct input words
+	AverageLength float64  // Mean length of the placed words
+	Reason        string   // Why generation failed, empty on success
+}
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+)
+
+// RenderPuzzlesParallel renders each puzzle to dir as "<prefix><n>.png",
+// numbered from 1, using the given number of workers. Every render parses
+// its own font and only reads the puzzle, so renders share no mutable
+// state. It returns the first error met, after all workers have stopped.
+func RenderPuzzlesParallel(puzzles []*Crossword, dir, prefix string, config RenderConfig, workers int) error {
+	workers = max(1, min(workers, len(puzzles)))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				path := filepath.Join(dir, fmt.Sprintf("%s%d.png", prefix, i+1))
+				if err := RenderPuzzleToPNG(puzzles[i], path, config); err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for i := range puzzles {
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return firstErr
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"fmt"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRenderPuzzlesParallel(t *testing.T) {
+	puzzles := make([]*Crossword, 6)
+	for i := range puzzles {
+		puzzles[i] = NewCrossword(8, 8)
+		puzzles[i].GeneratePuzzle(testWords[i : i+4])
+	}
+
+	tests := []struct {
+		name    string
+		puzzles []*Crossword
+		workers int
+	}{
+		{"one worker", puzzles, 1},
+		{"several workers", puzzles, 4},
+		{"same puzzle", []*Crossword{puzzles[0], puzzles[0], puzzles[0], puzzles[0]}, 4},
+		{"more workers than puzzles", puzzles[:2], 8},
+		{"no workers", puzzles[:3], 0},
+		{"no puzzles", nil, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := RenderPuzzlesParallel(tt.puzzles, dir, "puzzle", DefaultConfig(), tt.workers); err != nil {
+				t.Fatalf("RenderPuzzlesParallel() error = %v", err)
+			}
+
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != len(tt.puzzles) {
+				t.Fatalf("%d files written, want %d", len(entries), len(tt.puzzles))
+			}
+
+			for i := range tt.puzzles {
+				f, err := os.Open(filepath.Join(dir, fmt.Sprintf("puzzle%d.png", i+1)))
+				if err != nil {
+					t.Fatal(err)
+				}
+				_, err = png.Decode(f)
+				f.Close()
+				if err != nil {
+					t.Errorf("puzzle%d.png: %v", i+1, err)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderPuzzlesParallelError(t *testing.T) {
+	puzzles := []*Crossword{crossingPuzzle(), crossingPuzzle(), crossingPuzzle()}
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	if err := RenderPuzzlesParallel(puzzles, missing, "puzzle", DefaultConfig(), 2); err == nil {
+		t.Error("RenderPuzzlesParallel() into a missing directory succeeded")
+	}
+}
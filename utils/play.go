@@ -0,0 +1,44 @@
+package utils
+
+import "math/rand"
+
+// RevealLetter picks a random letter cell that is blank or wrong in the
+// player's attempt and returns its position and correct letter. ok is
+// false when the attempt is already complete.
+func (c *Crossword) RevealLetter(attempt [][]rune, rng *rand.Rand) (x, y int, letter rune, ok bool) {
+	var candidates [][2]int
+	for i := range c.board {
+		for j, cell := range c.board[i] {
+			if !isLetter(cell) {
+				continue
+			}
+
+			// Cells missing from the attempt count as blank
+			if i < len(attempt) && j < len(attempt[i]) && attempt[i][j] == cell {
+				continue
+			}
+			candidates = append(candidates, [2]int{i, j})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return 0, 0, 0, false
+	}
+
+	pick := candidates[rng.Intn(len(candidates))]
+	return pick[0], pick[1], c.board[pick[0]][pick[1]], true
+}
+
+// FillableCells returns the coordinates of every letter cell the player
+// has to fill
+func (c *Crossword) FillableCells() [][2]int {
+	var cells [][2]int
+	for x := range c.board {
+		for y, cell := range c.board[x] {
+			if isLetter(cell) {
+				cells = append(cells, [2]int{x, y})
+			}
+		}
+	}
+	return cells
+}
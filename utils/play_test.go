@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestRevealLetter(t *testing.T) {
+	c := crossingPuzzle()
+
+	wrong := c.BoardLetters()
+	wrong[1][3] = 'X'
+
+	blank := c.BoardLetters()
+	blank[3][1] = ' '
+
+	tests := []struct {
+		name    string
+		attempt [][]rune
+		cells   [][2]int // Cells that may be revealed, none when complete
+	}{
+		{"wrong letter", wrong, [][2]int{{1, 3}}},
+		{"blank letter", blank, [][2]int{{3, 1}}},
+		{"missing rows", c.BoardLetters()[:2], [][2]int{{2, 1}, {3, 1}, {4, 1}}},
+		{"complete", c.BoardLetters(), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for seed := int64(1); seed <= 5; seed++ {
+				x, y, letter, ok := c.RevealLetter(tt.attempt, rand.New(rand.NewSource(seed)))
+				if ok != (len(tt.cells) > 0) {
+					t.Fatalf("ok = %v, want %v", ok, len(tt.cells) > 0)
+				}
+				if !ok {
+					return
+				}
+
+				if !slices.Contains(tt.cells, [2]int{x, y}) {
+					t.Errorf("revealed (%d,%d), want one of %v", x, y, tt.cells)
+				}
+				if letter != c.GetBoard()[x][y] {
+					t.Errorf("revealed %q at (%d,%d), want %q", letter, x, y, c.GetBoard()[x][y])
+				}
+			}
+		})
+	}
+}
+
+func TestFillableCells(t *testing.T) {
+	tests := []struct {
+		name   string
+		puzzle func() *Crossword
+	}{
+		{"crossing", crossingPuzzle},
+		{"empty", func() *Crossword { return NewCrossword(5, 5) }},
+		{"generated", func() *Crossword {
+			c := NewCrossword(12, 12)
+			rng := rand.New(rand.NewSource(7))
+			c.GenerateWithOptions(testWords, GenerateOptions{Rand: rng.Intn})
+			return c
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.puzzle()
+
+			letters, shared := 0, 0
+			for _, p := range c.GetPlacements() {
+				letters += p.Length
+			}
+			for x := range c.board {
+				for y := range c.board[x] {
+					covering := 0
+					for _, p := range c.GetPlacements() {
+						if p.covers(x, y) {
+							covering++
+						}
+					}
+					shared += max(0, covering-1)
+				}
+			}
+
+			cells := c.FillableCells()
+			if want := letters - shared; len(cells) != want {
+				t.Errorf("%d fillable cells, want %d letters minus %d shared", len(cells), letters, shared)
+			}
+			for _, cell := range cells {
+				if !isLetter(c.board[cell[0]][cell[1]]) {
+					t.Errorf("cell %v holds %q", cell, c.board[cell[0]][cell[1]])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package utils
+
+import "sort"
+
+// cell returns the board coordinates of the i-th letter of a placement
+func (p WordPlacement) cell(i int) (int, int) {
+	if p.Dir == Horizontal {
+		return p.X, p.Y + i
+	}
+	return p.X + i, p.Y
+}
+
+// covers reports whether the placement occupies the given cell
+func (p WordPlacement) covers(x, y int) bool {
+	if p.Dir == Horizontal {
+		return x == p.X && y >= p.Y && y < p.Y+p.Length
+	}
+	return y == p.Y && x >= p.X && x < p.X+p.Length
+}
+
+// CrossingPlacements returns the placements intersecting the given one
+func (c *Crossword) CrossingPlacements(p WordPlacement) []WordPlacement {
+	var crossing []WordPlacement
+	for i := 0; i < p.Length; i++ {
+		x, y := p.cell(i)
+		if !c.isValidPosition(x, y) {
+			continue
+		}
+
+		// A crossing word runs in the other direction through this cell
+		other, dir := c.vWords[x][y], Vertical
+		if p.Dir == Vertical {
+			other, dir = c.hWords[x][y], Horizontal
+		}
+		if other == 0 {
+			continue
+		}
+
+		for _, q := range c.placements {
+			if q.Dir == dir && q.covers(x, y) {
+				crossing = append(crossing, q)
+				break
+			}
+		}
+	}
+	return crossing
+}
+
+// isLetter checks if a board cell holds a letter rather than a block or space
+func isLetter(r rune) bool {
+	return r != ' ' && r != '*'
+}
+
+// Entries returns every maximal horizontal and vertical run of two or more
+// letters, computed from the board alone
+func (c *Crossword) Entries() []WordPlacement {
+	var entries []WordPlacement
+
+	// scan collects the runs along one line of cells
+	scan := func(length int, at func(i int) rune, start func(i int) (int, int), dir Direction) {
+		for i := 0; i < length; {
+			if !isLetter(at(i)) {
+				i++
+				continue
+			}
+
+			j := i
+			var word []rune
+			for j < length && isLetter(at(j)) {
+				word = append(word, at(j))
+				j++
+			}
+
+			if len(word) >= 2 {
+				x, y := start(i)
+				entries = append(entries, WordPlacement{X: x, Y: y, Dir: dir, Length: len(word), Word: string(word)})
+			}
+			i = j
+		}
+	}
+
+	for x := 0; x < c.height; x++ {
+		scan(c.width,
+			func(i int) rune { return c.board[x][i] },
+			func(i int) (int, int) { return x, i },
+			Horizontal)
+	}
+	for y := 0; y < c.width; y++ {
+		scan(c.height,
+			func(i int) rune { return c.board[i][y] },
+			func(i int) (int, int) { return i, y },
+			Vertical)
+	}
+
+	return entries
+}
+
+// DoubleStartCells returns the cells, in reading order, where both an
+// across and a down entry begin
+func (c *Crossword) DoubleStartCells() [][2]int {
+	starts := make(map[[2]int]Direction)
+	var cells [][2]int
+	for _, e := range c.Entries() {
+		start := [2]int{e.X, e.Y}
+		if dir, ok := starts[start]; ok && dir != e.Dir {
+			cells = append(cells, start)
+		}
+		starts[start] = e.Dir
+	}
+
+	sort.Slice(cells, func(i, j int) bool {
+		if cells[i][0] != cells[j][0] {
+			return cells[i][0] < cells[j][0]
+		}
+		return cells[i][1] < cells[j][1]
+	})
+	return cells
+}
+
+// EntryWords returns the text of every entry on the board, whether placed
+// or formed by accident
+func (c *Crossword) EntryWords() []string {
+	entries := c.Entries()
+	words := make([]string, len(entries))
+	for i, e := range entries {
+		words[i] = e.Word
+	}
+	return words
+}
+
+// BlockRatio returns the ratio of block cells to all cells of the board
+func (c *Crossword) BlockRatio() float64 {
+	if c.width == 0 || c.height == 0 {
+		return 0
+	}
+
+	blocks := 0
+	for x := range c.board {
+		for _, cell := range c.board[x] {
+			if cell == '*' {
+				blocks++
+			}
+		}
+	}
+	return float64(blocks) / float64(c.width*c.height)
+}
+
+// IsRotationallySymmetric checks if the blocks look the same after turning
+// the board 180 degrees
+func (c *Crossword) IsRotationallySymmetric() bool {
+	for x := range c.board {
+		for y, cell := range c.board[x] {
+			if (cell == '*') != (c.board[c.height-1-x][c.width-1-y] == '*') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// LongestWord returns the placement with the greatest length, false when
+// nothing is placed
+func (c *Crossword) LongestWord() (WordPlacement, bool) {
+	if len(c.placements) == 0 {
+		return WordPlacement{}, false
+	}
+
+	longest := c.placements[0]
+	for _, p := range c.placements[1:] {
+		if p.Length > longest.Length {
+			longest = p
+		}
+	}
+	return longest, true
+}
+
+// CountByDirection returns the number of across and down placements
+func (c *Crossword) CountByDirection() (across, down int) {
+	for _, p := range c.placements {
+		if p.Dir == Horizontal {
+			across++
+		} else {
+			down++
+		}
+	}
+	return across, down
+}
+
+// CellOwners maps each letter cell to the numbers of the words occupying it
+func (c *Crossword) CellOwners() map[[2]int][]int {
+	owners := make(map[[2]int][]int)
+	for _, p := range c.placements {
+		for i := 0; i < p.Length; i++ {
+			x, y := p.cell(i)
+			owners[[2]int{x, y}] = append(owners[[2]int{x, y}], p.Number)
+		}
+	}
+	return owners
+}
+
+// NormalizedPlacement is a placement with coordinates and length expressed
+// as fractions of the grid dimensions
+type NormalizedPlacement struct {
+	X, Y, Len float64
+	Dir       Direction
+	Word      string
+}
+
+// NormalizedPlacements returns the placements scaled to [0,1], rows by the
+// grid height and columns by its width
+func (c *Crossword) NormalizedPlacements() []NormalizedPlacement {
+	normalized := make([]NormalizedPlacement, 0, len(c.placements))
+	for _, p := range c.placements {
+		length := float64(p.Length) / float64(c.width)
+		if p.Dir == Vertical {
+			length = float64(p.Length) / float64(c.height)
+		}
+
+		normalized = append(normalized, NormalizedPlacement{
+			X:    float64(p.X) / float64(c.height),
+			Y:    float64(p.Y) / float64(c.width),
+			Len:  length,
+			Dir:  p.Dir,
+			Word: p.Word,
+		})
+	}
+	return normalized
+}
+
+// Slot is an answer slot of the grid, with its cells in reading order
+type Slot struct {
+	Number int
+	Cells  [][2]int
+}
+
+// Slots returns the answer slots running in the given direction, ordered
+// by number
+func (c *Crossword) Slots(dir Direction) []Slot {
+	var slots []Slot
+	for _, p := range c.placements {
+		if p.Dir != dir {
+			continue
+		}
+
+		slot := Slot{Number: p.Number, Cells: make([][2]int, p.Length)}
+		for i := range slot.Cells {
+			x, y := p.cell(i)
+			slot.Cells[i] = [2]int{x, y}
+		}
+		slots = append(slots, slot)
+	}
+
+	sort.SliceStable(slots, func(i, j int) bool {
+		return slots[i].Number < slots[j].Number
+	})
+	return slots
+}
+
+// BoardLetters returns a copy of the board holding only letters and
+// spaces, with the internal block markers turned back into spaces
+func (c *Crossword) BoardLetters() [][]rune {
+	letters := make([][]rune, len(c.board))
+	for x := range c.board {
+		letters[x] = make([]rune, len(c.board[x]))
+		for y, cell := range c.board[x] {
+			letters[x][y] = cell
+			if cell == '*' {
+				letters[x][y] = ' '
+			}
+		}
+	}
+	return letters
+}
+
+// AttachableWords returns the candidates, not already placed, that could be
+// placed crossing at least one letter of the current board
+func (c *Crossword) AttachableWords(candidates []string) []string {
+	var attachable []string
+	for _, word := range candidates {
+		if !c.usedWords[word] && c.canAttach(word) {
+			attachable = append(attachable, word)
+		}
+	}
+	return attachable
+}
+
+// canAttach checks if a word has a valid placement with a crossing
+func (c *Crossword) canAttach(word string) bool {
+	for x := 0; x < c.height; x++ {
+		for y := 0; y < c.width; y++ {
+			for _, dir := range []Direction{Horizontal, Vertical} {
+				if c.canBePlaced(word, x, y, dir) > 0 {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
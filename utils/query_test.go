@@ -0,0 +1,435 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+// placementOf returns the placement of a word, failing the test when it
+// isn't placed
+func placementOf(t *testing.T, c *Crossword, word string) WordPlacement {
+	t.Helper()
+	for _, p := range c.GetPlacements() {
+		if p.Word == word {
+			return p
+		}
+	}
+	t.Fatalf("%s is not placed", word)
+	return WordPlacement{}
+}
+
+func TestCrossingPlacements(t *testing.T) {
+	c := crossingPuzzle()
+	c.putWord("RE", 4, 4, Horizontal)
+
+	tests := []struct {
+		word     string
+		crossing []string
+	}{
+		{"CASA", []string{"CANE"}},
+		{"CANE", []string{"CASA"}},
+		{"RE", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			var got []string
+			for _, q := range c.CrossingPlacements(placementOf(t, c, tt.word)) {
+				got = append(got, q.Word)
+			}
+			if !slices.Equal(got, tt.crossing) {
+				t.Errorf("CrossingPlacements(%s) = %v, want %v", tt.word, got, tt.crossing)
+			}
+		})
+	}
+}
+
+// accidentalPuzzle returns crossingPuzzle with an O written next to the
+// A of CANE and below the A of CASA, forming two unplaced runs
+func accidentalPuzzle() *Crossword {
+	c := crossingPuzzle()
+	c.board[2][2] = 'O'
+	return c
+}
+
+func TestEntries(t *testing.T) {
+	entries := accidentalPuzzle().Entries()
+
+	tests := []struct {
+		name  string
+		entry WordPlacement
+	}{
+		{"placed across", WordPlacement{X: 1, Y: 1, Dir: Horizontal, Length: 4, Word: "CASA"}},
+		{"placed down", WordPlacement{X: 1, Y: 1, Dir: Vertical, Length: 4, Word: "CANE"}},
+		{"accidental across", WordPlacement{X: 2, Y: 1, Dir: Horizontal, Length: 2, Word: "AO"}},
+		{"accidental down", WordPlacement{X: 1, Y: 2, Dir: Vertical, Length: 2, Word: "AO"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !slices.Contains(entries, tt.entry) {
+				t.Errorf("Entries() = %v, want it to contain %v", entries, tt.entry)
+			}
+		})
+	}
+	if len(entries) != len(tests) {
+		t.Errorf("Entries() has %d entries, want %d", len(entries), len(tests))
+	}
+}
+
+func TestBlockRatio(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *Crossword
+		want float64
+	}{
+		{"empty", NewCrossword(4, 4), 0},
+		{"crossing", crossingPuzzle(), 4.0 / 36},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.BlockRatio(); got != tt.want {
+				t.Errorf("BlockRatio() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLongestWord(t *testing.T) {
+	tied := NewCrossword(8, 8)
+	tied.putWord("ORSO", 0, 0, Horizontal)
+	tied.putWord("LUPO", 2, 0, Horizontal)
+
+	longer := crossingPuzzle()
+	longer.putWord("EBANO", 4, 1, Horizontal)
+
+	tests := []struct {
+		name string
+		c    *Crossword
+		want string
+		ok   bool
+	}{
+		{"empty", NewCrossword(5, 5), "", false},
+		{"single", crossingPuzzle(), "CASA", true},
+		{"longest wins", longer, "EBANO", true},
+		{"tie keeps first", tied, "ORSO", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.c.LongestWord()
+			if ok != tt.ok || got.Word != tt.want {
+				t.Errorf("LongestWord() = %q, %v, want %q, %v", got.Word, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestCountByDirection(t *testing.T) {
+	removed := crossingPuzzle()
+	removed.removeWord("CASA", 1, 1, Horizontal)
+
+	extended := crossingPuzzle()
+	extended.putWord("SOLE", 1, 3, Vertical)
+
+	tests := []struct {
+		name         string
+		c            *Crossword
+		across, down int
+	}{
+		{"empty", NewCrossword(5, 5), 0, 0},
+		{"crossing", crossingPuzzle(), 1, 1},
+		{"extended", extended, 1, 2},
+		{"after removal", removed, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			across, down := tt.c.CountByDirection()
+			if across != tt.across || down != tt.down {
+				t.Errorf("CountByDirection() = %d, %d, want %d, %d", across, down, tt.across, tt.down)
+			}
+		})
+	}
+}
+
+func TestCellOwners(t *testing.T) {
+	c := crossingPuzzle()
+	c.putWord("SOLE", 1, 3, Vertical)
+	c.AssignNumbers()
+	owners := c.CellOwners()
+
+	tests := []struct {
+		name string
+		cell [2]int
+		want []int
+	}{
+		{"shared start", [2]int{1, 1}, []int{1, 1}},
+		{"crossing", [2]int{1, 3}, []int{1, 2}},
+		{"across only", [2]int{1, 2}, []int{1}},
+		{"down only", [2]int{3, 3}, []int{2}},
+		{"empty", [2]int{4, 4}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slices.Clone(owners[tt.cell])
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("owners of %v = %v, want %v", tt.cell, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizedPlacements(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		word          string
+		x, y          int
+		dir           Direction
+		want          NormalizedPlacement
+	}{
+		{"center across", 10, 10, "CASA", 5, 5, Horizontal, NormalizedPlacement{X: 0.5, Y: 0.5, Len: 0.4, Dir: Horizontal, Word: "CASA"}},
+		{"center down", 10, 10, "CANE", 5, 5, Vertical, NormalizedPlacement{X: 0.5, Y: 0.5, Len: 0.4, Dir: Vertical, Word: "CANE"}},
+		{"wide grid across", 20, 10, "LUPO", 5, 10, Horizontal, NormalizedPlacement{X: 0.5, Y: 0.5, Len: 0.2, Dir: Horizontal, Word: "LUPO"}},
+		{"wide grid down", 20, 10, "LUPO", 0, 0, Vertical, NormalizedPlacement{X: 0, Y: 0, Len: 0.4, Dir: Vertical, Word: "LUPO"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCrossword(tt.width, tt.height)
+			c.putWord(tt.word, tt.x, tt.y, tt.dir)
+
+			got := c.NormalizedPlacements()
+			if len(got) != 1 {
+				t.Fatalf("got %d placements, want 1", len(got))
+			}
+			if got[0] != tt.want {
+				t.Errorf("NormalizedPlacements() = %+v, want %+v", got[0], tt.want)
+			}
+		})
+	}
+}
+
+func TestBoardLetters(t *testing.T) {
+	tests := []struct {
+		name   string
+		puzzle func() *Crossword
+	}{
+		{"crossing", crossingPuzzle},
+		{"empty", func() *Crossword { return NewCrossword(4, 3) }},
+		{"random blocks", func() *Crossword {
+			c := crossingPuzzle()
+			c.RandomBlocks(0.5, 1)
+			return c
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.puzzle()
+			letters := c.BoardLetters()
+
+			if len(letters) != len(c.board) {
+				t.Fatalf("%d rows, want %d", len(letters), len(c.board))
+			}
+			for x := range c.board {
+				for y, cell := range c.board[x] {
+					want := cell
+					if cell == '*' {
+						want = ' '
+					}
+					if letters[x][y] != want {
+						t.Errorf("cell (%d,%d) = %q, want %q", x, y, letters[x][y], want)
+					}
+				}
+			}
+
+			// The clean view is a copy
+			if len(letters) > 0 {
+				letters[0][0] = 'Z'
+				if c.board[0][0] == 'Z' {
+					t.Error("BoardLetters() shares the board")
+				}
+			}
+		})
+	}
+}
+
+func TestAttachableWords(t *testing.T) {
+	// GATTO alone across the middle of a 9x9 grid
+	c := NewCrossword(9, 9)
+	c.putWord("GATTO", 4, 2, Horizontal)
+
+	tests := []struct {
+		name       string
+		candidates []string
+		want       []string
+	}{
+		{"crossing letter", []string{"TOPO"}, []string{"TOPO"}},
+		{"no shared letter", []string{"LUPI"}, nil},
+		{"too long to cross", []string{"ABCDEFGHIJKLMNOP"}, nil},
+		{"already placed", []string{"GATTO"}, nil},
+		{"mixed", []string{"LUPI", "ORSO", "MUCCA", "ZEBU", "CANE"}, []string{"ORSO", "MUCCA", "CANE"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.AttachableWords(tt.candidates); !slices.Equal(got, tt.want) {
+				t.Errorf("AttachableWords(%v) = %v, want %v", tt.candidates, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEntryWords(t *testing.T) {
+	tests := []struct {
+		name   string
+		puzzle *Crossword
+		want   []string
+	}{
+		{"placed words only", crossingPuzzle(), []string{"CANE", "CASA"}},
+		{"accidental crossing", accidentalPuzzle(), []string{"AO", "AO", "CANE", "CASA"}},
+		{"empty", NewCrossword(3, 3), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.puzzle.EntryWords()
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("EntryWords() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsRotationallySymmetric(t *testing.T) {
+	template := func(width, height int, cells ...[2]int) func() *Crossword {
+		return func() *Crossword {
+			c := NewCrossword(width, height)
+			if err := c.SetTemplate(blockGrid(width, height, cells...)); err != nil {
+				t.Fatal(err)
+			}
+			return c
+		}
+	}
+
+	tests := []struct {
+		name   string
+		puzzle func() *Crossword
+		want   bool
+	}{
+		{"empty", func() *Crossword { return NewCrossword(5, 5) }, true},
+		{"symmetric template", template(5, 5, [2]int{0, 0}, [2]int{4, 4}, [2]int{1, 3}, [2]int{3, 1}), true},
+		{"center block", template(5, 5, [2]int{2, 2}), true},
+		{"symmetric wide template", template(6, 4, [2]int{0, 1}, [2]int{3, 4}), true},
+		{"lone corner", template(5, 5, [2]int{0, 0}), false},
+		{"mirrored instead of rotated", template(5, 5, [2]int{0, 0}, [2]int{0, 4}), false},
+		{"crossing", crossingPuzzle, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.puzzle().IsRotationallySymmetric(); got != tt.want {
+				t.Errorf("IsRotationallySymmetric() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoubleStartCells(t *testing.T) {
+	grid := func(rows ...string) func() *Crossword {
+		return func() *Crossword {
+			c, err := CrosswordFromGrid(rows)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return c
+		}
+	}
+
+	tests := []struct {
+		name   string
+		puzzle func() *Crossword
+		want   [][2]int
+	}{
+		{"shared start", crossingPuzzle, [][2]int{{1, 1}}},
+		{"down start only", func() *Crossword {
+			c := crossingPuzzle()
+			c.putWord("SOLE", 1, 3, Vertical)
+			return c
+		}, [][2]int{{1, 1}}},
+		{"accidental words", accidentalPuzzle, [][2]int{{1, 1}}},
+		{"two shared starts", grid(
+			"CASA.",
+			"A....",
+			"N.RE.",
+			"E.E..",
+		), [][2]int{{0, 0}, {2, 2}}},
+		{"apart", grid(
+			"GATTO",
+			".....",
+			"L....",
+			"U....",
+		), nil},
+		{"empty", func() *Crossword { return NewCrossword(3, 3) }, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.puzzle().DoubleStartCells(); !slices.Equal(got, tt.want) {
+				t.Errorf("DoubleStartCells() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlots(t *testing.T) {
+	grid, err := CrosswordFromGrid([]string{
+		"CASA.",
+		"A....",
+		"N.RE.",
+		"E.E..",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		puzzle *Crossword
+		dir    Direction
+		want   []Slot
+	}{
+		{"across", crossingPuzzle(), Horizontal, []Slot{
+			{Number: 1, Cells: [][2]int{{1, 1}, {1, 2}, {1, 3}, {1, 4}}},
+		}},
+		{"down", crossingPuzzle(), Vertical, []Slot{
+			{Number: 1, Cells: [][2]int{{1, 1}, {2, 1}, {3, 1}, {4, 1}}},
+		}},
+		{"across by number", grid, Horizontal, []Slot{
+			{Number: 1, Cells: [][2]int{{0, 0}, {0, 1}, {0, 2}, {0, 3}}},
+			{Number: 2, Cells: [][2]int{{2, 2}, {2, 3}}},
+		}},
+		{"down by number", grid, Vertical, []Slot{
+			{Number: 1, Cells: [][2]int{{0, 0}, {1, 0}, {2, 0}, {3, 0}}},
+			{Number: 2, Cells: [][2]int{{2, 2}, {3, 2}}},
+		}},
+		{"empty", NewCrossword(4, 4), Horizontal, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.puzzle.Slots(tt.dir)
+			if !slices.EqualFunc(got, tt.want, func(a, b Slot) bool {
+				return a.Number == b.Number && slices.Equal(a.Cells, b.Cells)
+			}) {
+				t.Errorf("Slots(%v) = %v, want %v", tt.dir, got, tt.want)
+			}
+		})
+	}
+}
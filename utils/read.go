@@ -3,7 +3,10 @@ package utils
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
+	"os"
+	"slices"
 )
 
 // Data represents the structure of each object in the JSON array
@@ -30,3 +33,101 @@ func ReadWords() []Data {
 
 	return payload
 }
+
+// StreamWords decodes the JSON array at path one element at a time, calling
+// fn for each. Decoding stops early when fn returns false.
+func StreamWords(path string, fn func(Data) bool) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	decoder := json.NewDecoder(f)
+
+	// Expect the opening bracket of the array
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("%s: expected a JSON array", path)
+	}
+
+	for decoder.More() {
+		var item Data
+		if err := decoder.Decode(&item); err != nil {
+			return err
+		}
+		if !fn(item) {
+			return nil
+		}
+	}
+
+	// Consume the closing bracket
+	_, err = decoder.Token()
+	return err
+}
+
+// ReadWordsMerged reads the JSON word files at paths in order and
+// concatenates them. A word appearing more than once keeps its first
+// position, with the clues of every copy merged without repeats.
+func ReadWordsMerged(paths ...string) ([]Data, error) {
+	var merged []Data
+	index := make(map[string]int)
+
+	for _, path := range paths {
+		err := StreamWords(path, func(item Data) bool {
+			i, ok := index[item.Nome]
+			if !ok {
+				index[item.Nome] = len(merged)
+				merged = append(merged, Data{Nome: item.Nome})
+				i = len(merged) - 1
+			}
+			for _, desc := range item.Desc {
+				if !slices.Contains(merged[i].Desc, desc) {
+					merged[i].Desc = append(merged[i].Desc, desc)
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %v", path, err)
+		}
+	}
+
+	return merged, nil
+}
+
+// MultiLangData is a word with clues grouped by language tag, such as
+// {"nome": "gatto", "desc": {"en": ["Cat"], "it": ["Felino"]}}
+type MultiLangData struct {
+	Nome string              `json:"nome"`
+	Desc map[string][]string `json:"desc"`
+}
+
+// ReadWordsMultilang reads a JSON array of words with language-tagged clues
+func ReadWordsMultilang(path string) ([]MultiLangData, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload []MultiLangData
+	if err := json.Unmarshal(content, &payload); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return payload, nil
+}
+
+// CluesIn maps each word to its first clue in the given language, for use
+// with SetClues. Words without a clue in that language are left out.
+func CluesIn(data []MultiLangData, lang string) map[string]string {
+	clues := make(map[string]string)
+	for _, item := range data {
+		if desc := item.Desc[lang]; len(desc) > 0 {
+			clues[item.Nome] = desc[0]
+		}
+	}
+	return clues
+}
@@ -0,0 +1,205 @@
+package utils
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// writeWords writes content to a JSON file in a temporary directory and
+// returns its path
+func writeWords(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "words.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestStreamWords(t *testing.T) {
+	path := writeWords(t, `[
+		{"nome": "gatto", "desc": ["Felino domestico"]},
+		{"nome": "cane", "desc": ["Migliore amico"]},
+		{"nome": "topo", "desc": ["Roditore"]},
+		{"nome": "lupo", "desc": ["Ulula"]}
+	]`)
+
+	tests := []struct {
+		name string
+		stop int // number of items after which fn returns false, 0 for never
+		want []string
+	}{
+		{"all", 0, []string{"gatto", "cane", "topo", "lupo"}},
+		{"stop after first", 1, []string{"gatto"}},
+		{"stop after third", 3, []string{"gatto", "cane", "topo"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			err := StreamWords(path, func(item Data) bool {
+				got = append(got, item.Nome)
+				return tt.stop == 0 || len(got) < tt.stop
+			})
+			if err != nil {
+				t.Fatalf("StreamWords() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("streamed %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamWordsInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"object", `{"nome": "gatto"}`},
+		{"truncated", `[{"nome": "gatto"}, {"nome": `},
+		{"wrong type", `[{"nome": 3}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := StreamWords(writeWords(t, tt.content), func(Data) bool { return true })
+			if err == nil {
+				t.Error("StreamWords() succeeded, want an error")
+			}
+		})
+	}
+
+	if err := StreamWords(filepath.Join(t.TempDir(), "missing.json"), func(Data) bool { return true }); err == nil {
+		t.Error("StreamWords() on a missing file succeeded, want an error")
+	}
+}
+
+func TestReadWordsMerged(t *testing.T) {
+	animals := writeWords(t, `[
+		{"nome": "gatto", "desc": ["Felino domestico"]},
+		{"nome": "cane", "desc": ["Migliore amico", "Abbaia"]}
+	]`)
+	pets := writeWords(t, `[
+		{"nome": "cane", "desc": ["Abbaia", "Fedele compagno"]},
+		{"nome": "criceto", "desc": ["Corre sulla ruota"]}
+	]`)
+
+	tests := []struct {
+		name  string
+		paths []string
+		want  []Data
+	}{
+		{"one file", []string{animals}, []Data{
+			{Nome: "gatto", Desc: []string{"Felino domestico"}},
+			{Nome: "cane", Desc: []string{"Migliore amico", "Abbaia"}},
+		}},
+		{"overlapping word", []string{animals, pets}, []Data{
+			{Nome: "gatto", Desc: []string{"Felino domestico"}},
+			{Nome: "cane", Desc: []string{"Migliore amico", "Abbaia", "Fedele compagno"}},
+			{Nome: "criceto", Desc: []string{"Corre sulla ruota"}},
+		}},
+		{"same file twice", []string{pets, pets}, []Data{
+			{Nome: "cane", Desc: []string{"Abbaia", "Fedele compagno"}},
+			{Nome: "criceto", Desc: []string{"Corre sulla ruota"}},
+		}},
+		{"no files", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadWordsMerged(tt.paths...)
+			if err != nil {
+				t.Fatalf("ReadWordsMerged() error = %v", err)
+			}
+			if !slices.EqualFunc(got, tt.want, func(a, b Data) bool {
+				return a.Nome == b.Nome && slices.Equal(a.Desc, b.Desc)
+			}) {
+				t.Errorf("ReadWordsMerged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadWordsMergedMissingFile(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.json")
+	valid := writeWords(t, `[{"nome": "gatto", "desc": ["Felino"]}]`)
+
+	got, err := ReadWordsMerged(valid, missing)
+	if err == nil {
+		t.Fatalf("ReadWordsMerged() = %v, want an error", got)
+	}
+	if !strings.Contains(err.Error(), missing) {
+		t.Errorf("error %q does not name %s", err, missing)
+	}
+}
+
+func TestReadWordsMultilang(t *testing.T) {
+	path := writeWords(t, `[
+		{"nome": "GATTO", "desc": {"en": ["Cat"], "it": ["Felino domestico", "Fa le fusa"]}},
+		{"nome": "CANE", "desc": {"en": ["Dog"], "it": ["Migliore amico"]}},
+		{"nome": "TOPO", "desc": {"en": ["Mouse"]}}
+	]`)
+
+	data, err := ReadWordsMultilang(path)
+	if err != nil {
+		t.Fatalf("ReadWordsMultilang() error = %v", err)
+	}
+	if len(data) != 3 || !slices.Equal(data[0].Desc["it"], []string{"Felino domestico", "Fa le fusa"}) {
+		t.Fatalf("ReadWordsMultilang() = %v", data)
+	}
+
+	tests := []struct {
+		name string
+		lang string
+		want map[string]string
+	}{
+		{"italian", "it", map[string]string{"GATTO": "Felino domestico", "CANE": "Migliore amico"}},
+		{"english", "en", map[string]string{"GATTO": "Cat", "CANE": "Dog", "TOPO": "Mouse"}},
+		{"missing language", "fr", map[string]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clues := CluesIn(data, tt.lang)
+			if !maps.Equal(clues, tt.want) {
+				t.Fatalf("CluesIn(%q) = %v, want %v", tt.lang, clues, tt.want)
+			}
+
+			// The chosen clues reach the placements of a generated puzzle
+			c := NewCrossword(10, 10)
+			if !c.GeneratePuzzle([]string{"GATTO", "CANE", "TOPO"}) {
+				t.Fatal("GeneratePuzzle() failed")
+			}
+			c.SetClues(clues)
+			for _, p := range c.GetPlacements() {
+				if p.Clue != tt.want[p.Word] {
+					t.Errorf("clue of %s = %q, want %q", p.Word, p.Clue, tt.want[p.Word])
+				}
+			}
+		})
+	}
+}
+
+func TestReadWordsMultilangInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+	}{
+		{"missing file", filepath.Join(t.TempDir(), "missing.json")},
+		{"single language shape", writeWords(t, `[{"nome": "gatto", "desc": ["Felino"]}]`)},
+		{"not json", writeWords(t, `gatto`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := ReadWordsMultilang(tt.path); err == nil {
+				t.Errorf("ReadWordsMultilang() = %v, want an error", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// PlaceRebus places a word given cell by cell, so a single cell may hold
+// several letters: "ROMA" as "R", "O", "MA" takes three cells. The board
+// keeps the first letter of each rebus cell, and a rebus cell can only be
+// crossed by another rebus word holding the same letters there.
+func (c *Crossword) PlaceRebus(cells []string, x, y int, dir Direction) error {
+	if len(cells) == 0 {
+		return fmt.Errorf("rebus word has no cells")
+	}
+
+	word := strings.Join(cells, "")
+	if c.usedWords[word] {
+		return fmt.Errorf("word %q is already placed", word)
+	}
+
+	p := WordPlacement{X: x, Y: y, Dir: dir, Length: len(cells), Word: word}
+	for i, letters := range cells {
+		if letters == "" {
+			return fmt.Errorf("cell %d of %q is empty", i, word)
+		}
+
+		x1, y1 := p.cell(i)
+		if !c.cellOpen(x1, y1, dir) {
+			return fmt.Errorf("cell (%d,%d) is not available", x1, y1)
+		}
+		if c.board[x1][y1] != ' ' && c.cellText(x1, y1) != letters {
+			return fmt.Errorf("cell (%d,%d) holds %q, not %q", x1, y1, c.cellText(x1, y1), letters)
+		}
+	}
+
+	if !c.endsOpen(x, y, len(cells), dir) {
+		return fmt.Errorf("word %q runs into another word", word)
+	}
+
+	for i, letters := range cells {
+		x1, y1 := p.cell(i)
+		first, _ := utf8.DecodeRuneInString(letters)
+		c.board[x1][y1] = first
+		if utf8.RuneCountInString(letters) > 1 {
+			if c.rebus == nil {
+				c.rebus = make(map[[2]int]string)
+			}
+			c.rebus[[2]int{x1, y1}] = letters
+		}
+	}
+
+	// Place blocking characters
+	for _, i := range []int{-1, p.Length} {
+		if x1, y1 := p.cell(i); c.isValidPosition(x1, y1) {
+			c.board[x1][y1] = '*'
+		}
+	}
+
+	c.registerPlacement(p)
+	c.frontier = nil
+	return nil
+}
+
+// RebusCells returns the multi-letter cells keyed by {row, col}
+func (c *Crossword) RebusCells() map[[2]int]string {
+	return c.rebus
+}
+
+// cellText returns the letters held by a cell, which is more than one for
+// rebus cells
+func (c *Crossword) cellText(x, y int) string {
+	if letters, ok := c.rebus[[2]int{x, y}]; ok {
+		return letters
+	}
+	return string(c.board[x][y])
+}
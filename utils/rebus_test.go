@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"maps"
+	"testing"
+)
+
+// rebusPuzzle returns a 6x6 grid where ROMA across and MARE down share the
+// rebus cell "MA" at (1,3)
+func rebusPuzzle(t testing.TB) *Crossword {
+	t.Helper()
+	c := NewCrossword(6, 6)
+	if err := c.PlaceRebus([]string{"R", "O", "MA"}, 1, 1, Horizontal); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.PlaceRebus([]string{"MA", "R", "E"}, 1, 3, Vertical); err != nil {
+		t.Fatal(err)
+	}
+	c.AssignNumbers()
+	return c
+}
+
+func TestPlaceRebus(t *testing.T) {
+	c := rebusPuzzle(t)
+
+	if !c.PlacementsConsistent() {
+		t.Error("placements don't match the board")
+	}
+	if want := map[[2]int]string{{1, 3}: "MA"}; !maps.Equal(c.RebusCells(), want) {
+		t.Errorf("RebusCells() = %v, want %v", c.RebusCells(), want)
+	}
+	for _, word := range []string{"ROMA", "MARE"} {
+		if p := placementOf(t, c, word); p.Length != 3 {
+			t.Errorf("%s spans %d cells, want 3", word, p.Length)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		cells []string
+		x, y  int
+		dir   Direction
+	}{
+		{"no cells", nil, 4, 0, Horizontal},
+		{"empty cell", []string{"S", "", "LE"}, 4, 0, Horizontal},
+		{"single letter on the rebus cell", []string{"M", "E"}, 1, 3, Vertical},
+		{"other letters on the rebus cell", []string{"MO", "RE"}, 1, 3, Vertical},
+		{"already placed", []string{"R", "O", "MA"}, 4, 0, Horizontal},
+		{"off the board", []string{"SO", "L", "E"}, 4, 4, Horizontal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := c.Clone()
+			if err := c.PlaceRebus(tt.cells, tt.x, tt.y, tt.dir); err == nil {
+				t.Errorf("PlaceRebus(%q) succeeded, want an error", tt.cells)
+			}
+			if !c.Equal(before) {
+				t.Error("failed placement changed the board")
+			}
+		})
+	}
+}
+
+func TestRebusSurvivesHashEncodeAndScramble(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(c *Crossword)
+	}{
+		{"rebus text changed", func(c *Crossword) { c.rebus[[2]int{1, 3}] = "MI" }},
+		{"rebus text extended", func(c *Crossword) { c.rebus[[2]int{1, 3}] = "MAR" }},
+		{"rebus dropped", func(c *Crossword) { delete(c.rebus, [2]int{1, 3}) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := rebusPuzzle(t)
+			other := c.Clone()
+			tt.mutate(other)
+			if c.Hash() == other.Hash() {
+				t.Error("hash ignores the rebus text")
+			}
+			if c.Encode() == other.Encode() {
+				t.Error("encoding ignores the rebus text")
+			}
+		})
+	}
+
+	c := rebusPuzzle(t)
+	t.Run("decode", func(t *testing.T) {
+		encoded := c.Encode()
+		decoded, err := Decode(encoded)
+		if err != nil {
+			t.Fatalf("Decode(%q) error = %v", encoded, err)
+		}
+		if !maps.Equal(decoded.RebusCells(), c.RebusCells()) {
+			t.Errorf("decoded rebus cells %v, want %v", decoded.RebusCells(), c.RebusCells())
+		}
+		if decoded.Hash() != c.Hash() {
+			t.Errorf("Decode(%q) hashes differently", encoded)
+		}
+	})
+
+	t.Run("scramble", func(t *testing.T) {
+		scrambled := c.ScrambleRebus(7)
+		if scrambled[[2]int{1, 3}] == "MA" || len(scrambled) != 1 {
+			t.Errorf("ScrambleRebus() = %v, want the rebus letters substituted", scrambled)
+		}
+		// The first letter matches the scrambled board
+		if got, want := []rune(scrambled[[2]int{1, 3}])[0], c.Scramble(7)[1][3]; got != want {
+			t.Errorf("scrambled rebus starts with %q, board holds %q", got, want)
+		}
+		if got := UnscrambleRebus(scrambled, 7); !maps.Equal(got, c.RebusCells()) {
+			t.Errorf("UnscrambleRebus() = %v, want %v", got, c.RebusCells())
+		}
+	})
+}
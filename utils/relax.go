@@ -0,0 +1,56 @@
+package utils
+
+// relaxations loosen one constraint each, in the order GenerateRelaxing
+// applies them, reporting whether there was anything to loosen
+var relaxations = []func(opts *GenerateOptions) bool{
+	func(opts *GenerateOptions) bool {
+		relaxed := opts.RequireAll
+		opts.RequireAll = false
+		return relaxed
+	},
+	func(opts *GenerateOptions) bool {
+		relaxed := opts.MinFillRatio > 0
+		opts.MinFillRatio = 0
+		return relaxed
+	},
+	func(opts *GenerateOptions) bool {
+		relaxed := opts.MaxBlockRatio > 0
+		opts.MaxBlockRatio = 0
+		return relaxed
+	},
+	func(opts *GenerateOptions) bool {
+		relaxed := opts.EdgeMargin > 0
+		opts.EdgeMargin = 0
+		return relaxed
+	},
+	func(opts *GenerateOptions) bool {
+		relaxed := opts.MaxAcross > 0 || opts.MaxDown > 0
+		opts.MaxAcross, opts.MaxDown = 0, 0
+		return relaxed
+	},
+}
+
+// GenerateRelaxing generates a puzzle of the given size, dropping one
+// constraint after another until generation succeeds: first RequireAll,
+// then MinFillRatio, MaxBlockRatio, EdgeMargin, and finally MaxAcross with
+// MaxDown. Constraints already off are skipped. It returns the last
+// puzzle, its result and the options it was generated with, which show
+// what was relaxed.
+func GenerateRelaxing(words []string, width, height int, opts GenerateOptions) (*Crossword, GenerateResult, GenerateOptions) {
+	c := NewCrossword(width, height)
+	result := c.GenerateWithOptions(words, opts)
+
+	for _, relax := range relaxations {
+		if result.Success {
+			break
+		}
+		if !relax(&opts) {
+			continue
+		}
+
+		c = NewCrossword(width, height)
+		result = c.GenerateWithOptions(words, opts)
+	}
+
+	return c, result, opts
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestGenerateRelaxing(t *testing.T) {
+	words := []string{"GATTO", "TOPO", "ORSO", "CANE", "PAPPAGALLOVERDE"}
+
+	tests := []struct {
+		name    string
+		words   []string
+		opts    GenerateOptions
+		want    GenerateOptions
+		success bool
+	}{
+		{"nothing to relax", words[:4], GenerateOptions{}, GenerateOptions{}, true},
+		{"strict enough", words[:4], GenerateOptions{RequireAll: true}, GenerateOptions{RequireAll: true}, true},
+		{"require all", words, GenerateOptions{RequireAll: true}, GenerateOptions{}, true},
+		{"require all and edge margin", words, GenerateOptions{RequireAll: true, EdgeMargin: 4, MaxAcross: 3},
+			GenerateOptions{MaxAcross: 3}, true},
+		{"fill ratio", words, GenerateOptions{MinFillRatio: 1, MaxDown: 2}, GenerateOptions{MaxDown: 2}, true},
+		{"caps kept", words[:4], GenerateOptions{RequireAll: true, MaxAcross: 1, MaxDown: 1}, GenerateOptions{MaxAcross: 1, MaxDown: 1}, true},
+		{"nothing fits", []string{"PAPPAGALLOVERDE"}, GenerateOptions{RequireAll: true, EdgeMargin: 1},
+			GenerateOptions{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Rand = rand.New(rand.NewSource(1)).Intn
+			c, result, used := GenerateRelaxing(tt.words, 10, 10, tt.opts)
+
+			if result.Success != tt.success {
+				t.Fatalf("Success = %v, want %v (reason %q)", result.Success, tt.success, result.Reason)
+			}
+			used.Rand = nil
+			if !reflect.DeepEqual(used, tt.want) {
+				t.Errorf("options used = %+v, want %+v", used, tt.want)
+			}
+			if c == nil || c.width != 10 || c.height != 10 {
+				t.Fatal("no 10x10 puzzle returned")
+			}
+			if tt.success && len(c.GetPlacements()) == 0 {
+				t.Error("successful puzzle has no words")
+			}
+		})
+	}
+}
@@ -6,13 +6,21 @@ import (
 	"image"
 	"image/color"
 	"image/draw"
+	"image/jpeg"
 	"image/png"
+	"math"
+	"math/rand"
 	"os"
+	"sort"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/golang/freetype"
 	"github.com/golang/freetype/truetype"
 	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/vector"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
 )
 
 // RenderConfig holds configuration for rendering the crossword
@@ -24,8 +32,36 @@ type RenderConfig struct {
 	GridLineColor   color.Color
 	BlockColor      color.Color
 	LetterColor     color.Color
+	FontBytes       []byte        // TrueType font data, nil uses the built-in Go font
+	AntiAlias       bool          // Draw grid lines anti-aliased and 1px thick, ignored with a CellGap, a mask or a CornerRadius
+	ShowSolution    bool          // Draw the letters, false renders a blank numbered grid
+	Language        string        // BCP 47 tag for locale-aware uppercasing, empty uses strings.ToUpper
+	CircledCells    [][2]int      // Cells, as {X, Y} board coordinates, drawn with an inscribed circle
+	ShowWordBank    bool          // List the placed words, shuffled, in a column right of the grid
+	WordColors      []color.Color // Palette tinting each word's cells by number, used cyclically
+	FitAspect       float64       // Target page width/height, the grid is turned when that fits better
+	ShowTitle       bool          // Print the puzzle title, if any, above the grid
+	HighlightCells  [][2]int      // Cells, as {X, Y} board coordinates, drawn highlighted
+	HighlightColor  color.Color   // Fill of highlighted cells, nil leaves them unfilled
+	ShadePattern    ShadePattern  // Texture drawn over highlighted cells
+	ShowAnswerKey   bool          // List the numbered answers below the solution grid
+	CellGap         int           // Space between cell tiles, 0 draws shared borders
+	EmptyCellColor  color.Color   // Fill of the cells to solve in blank renders, nil keeps the background
+	DPI             float64       // Output resolution, pixel sizes are given at 72 DPI and scaled
+	Footer          string        // Text centered in a smaller font below everything else, empty for none
+	BorderScale     float64       // Border as a fraction of CellSize, overrides BorderSize and outlines cells as thick; 0 keeps 1px outlines
+	CornerRadius    int           // Radius of rounded cell corners, 0 keeps them square
 }
 
+// ShadePattern is a texture marking highlighted cells without relying on color
+type ShadePattern int
+
+const (
+	PatternNone     ShadePattern = 0
+	PatternDots     ShadePattern = 1
+	PatternDiagonal ShadePattern = 2
+)
+
 // DefaultConfig returns a default rendering configuration
 func DefaultConfig() RenderConfig {
 	return RenderConfig{
@@ -36,11 +72,85 @@ func DefaultConfig() RenderConfig {
 		GridLineColor:   color.Black,
 		BlockColor:      color.Black,
 		LetterColor:     color.Black,
+		ShowSolution:    true,
+		DPI:             72,
 	}
 }
 
 // RenderPuzzleToPNG creates a PNG image of the crossword puzzle
 func RenderPuzzleToPNG(puzzle *Crossword, filename string, config RenderConfig) error {
+	img, err := RenderPuzzleImage(puzzle, config)
+	if err != nil {
+		return err
+	}
+
+	// Save to file
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return png.Encode(f, img)
+}
+
+// RenderPuzzleToJPEG creates a JPEG image of the crossword puzzle with the given quality (1-100)
+func RenderPuzzleToJPEG(puzzle *Crossword, filename string, quality int, config RenderConfig) error {
+	img, err := RenderPuzzleImage(puzzle, config)
+	if err != nil {
+		return err
+	}
+
+	// Save to file
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return jpeg.Encode(f, img, &jpeg.Options{Quality: quality})
+}
+
+// RenderPuzzlePair renders a blank numbered grid and its solution to two PNG files
+func RenderPuzzlePair(puzzle *Crossword, blankPath, solutionPath string, config RenderConfig) error {
+	config.ShowSolution = false
+	if err := RenderPuzzleToPNG(puzzle, blankPath, config); err != nil {
+		return err
+	}
+
+	config.ShowSolution = true
+	return RenderPuzzleToPNG(puzzle, solutionPath, config)
+}
+
+// RenderPuzzleImage draws the crossword puzzle into an in-memory image
+func RenderPuzzleImage(puzzle *Crossword, config RenderConfig) (*image.RGBA, error) {
+	// Turn the grid a quarter when that better fills the page
+	if config.FitAspect > 0 && betterTurned(puzzle.width, puzzle.height, config.FitAspect) {
+		puzzle = puzzle.transposed()
+		config.CircledCells = swapCells(config.CircledCells)
+		config.HighlightCells = swapCells(config.HighlightCells)
+	}
+
+	// Scale pixel sizes to the resolution, font sizes stay in points
+	dpi := config.DPI
+	if dpi <= 0 {
+		dpi = 72
+	}
+	scale := dpi / 72
+	config.CellSize = int(math.Round(float64(config.CellSize) * scale))
+	config.BorderSize = int(math.Round(float64(config.BorderSize) * scale))
+	config.CellGap = int(math.Round(float64(config.CellGap) * scale))
+	config.CornerRadius = int(math.Round(float64(config.CornerRadius) * scale))
+	fontPx := config.FontSize * scale // Font size in pixels
+
+	// Cell outlines are 1px inside a BorderSize margin, a scaled border
+	// replaces the margin and is drawn as thick
+	lineWidth := 1
+	if config.BorderScale > 0 {
+		config.BorderSize = max(1, int(math.Round(float64(config.CellSize)*config.BorderScale)))
+		lineWidth = config.BorderSize
+	}
+
 	board := puzzle.GetBoard()
 	height := len(board)
 	width := len(board[0])
@@ -49,113 +159,446 @@ func RenderPuzzleToPNG(puzzle *Crossword, filename string, config RenderConfig)
 	imgWidth := width*config.CellSize + config.BorderSize
 	imgHeight := height*config.CellSize + config.BorderSize
 
+	// Make room for the word bank
+	var bankWords []string
+	lineHeight := int(fontPx * 1.2)
+	gridWidth := imgWidth
+	if config.ShowWordBank {
+		longest := 0
+		for _, placement := range puzzle.GetPlacements() {
+			bankWords = append(bankWords, placement.Word)
+			longest = max(longest, utf8.RuneCountInString(placement.Word))
+		}
+		rand.Shuffle(len(bankWords), func(i, j int) {
+			bankWords[i], bankWords[j] = bankWords[j], bankWords[i]
+		})
+
+		imgWidth += int(float64(longest)*fontPx*0.6) + config.CellSize
+		imgHeight = max(imgHeight, len(bankWords)*lineHeight+config.CellSize)
+	}
+
 	// Create new image
 	img := image.NewRGBA(image.Rect(0, 0, imgWidth, imgHeight))
 
 	// Fill background
 	draw.Draw(img, img.Bounds(), &image.Uniform{config.BackgroundColor}, image.Point{}, draw.Src)
 
+	// Pick letter casing
+	toUpper := strings.ToUpper
+	if config.Language != "" {
+		tag, err := language.Parse(config.Language)
+		if err != nil {
+			return nil, err
+		}
+		toUpper = cases.Upper(tag).String
+	}
+
 	// Load font
-	font, err := truetype.Parse(goregular.TTF)
+	fontBytes := config.FontBytes
+	if fontBytes == nil {
+		fontBytes = goregular.TTF
+	}
+	font, err := truetype.Parse(fontBytes)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Create font context
 	fontContext := freetype.NewContext()
-	fontContext.SetDPI(72)
+	fontContext.SetDPI(dpi)
 	fontContext.SetFont(font)
 	fontContext.SetFontSize(config.FontSize)
 	fontContext.SetClip(img.Bounds())
 	fontContext.SetDst(img)
 	fontContext.SetSrc(image.NewUniform(config.LetterColor))
 
+	// Continuous anti-aliased lines can't leave gaps between tiles, skip
+	// masked-out cells or round corners
+	antiAlias := config.AntiAlias && config.CellGap == 0 && puzzle.mask == nil && config.CornerRadius == 0
+	if antiAlias {
+		drawGridAA(img, height, width, config.CellSize, config.GridLineColor)
+	}
+
+	var owners map[[2]int][]int
+	if len(config.WordColors) > 0 {
+		owners = puzzle.CellOwners()
+	}
+
+	circled := make(map[[2]int]bool, len(config.CircledCells))
+	for _, cell := range config.CircledCells {
+		circled[cell] = true
+	}
+	highlighted := make(map[[2]int]bool, len(config.HighlightCells))
+	for _, cell := range config.HighlightCells {
+		highlighted[cell] = true
+	}
+
 	// Draw grid and fill cells
 	for y := 0; y < height; y++ {
 		for x := 0; x < width; x++ {
+			// Masked-out cells are not part of the puzzle shape
+			if !puzzle.isValidPosition(y, x) {
+				continue
+			}
+
 			cell := board[y][x]
 			cellX := x * config.CellSize
 			cellY := y * config.CellSize
 
+			// The drawn tile is inset by the gap, its inside by the border
+			tileX := cellX + config.CellGap/2
+			tileY := cellY + config.CellGap/2
+			tileSize := config.CellSize - config.CellGap
+			innerSize := tileSize - 2*config.BorderSize
+			innerRadius := max(config.CornerRadius-config.BorderSize, 0)
+
 			// Draw cell border
-			drawRect(img, cellX, cellY, config.CellSize, config.CellSize, config.GridLineColor)
+			if !antiAlias {
+				drawRect(img, tileX, tileY, tileSize, tileSize, config.CornerRadius, lineWidth, config.GridLineColor)
+			}
+
+			// Mark the playable area of a blank grid
+			if isLetter(cell) && !config.ShowSolution && config.EmptyCellColor != nil {
+				fillRect(img,
+					tileX+config.BorderSize,
+					tileY+config.BorderSize,
+					innerSize,
+					innerSize,
+					innerRadius,
+					config.EmptyCellColor)
+			}
+
+			// Tint letter cells by word, blending at intersections
+			if numbers := owners[[2]int{y, x}]; len(numbers) > 0 {
+				var tints []color.Color
+				for _, number := range numbers {
+					tints = append(tints, config.WordColors[max(number-1, 0)%len(config.WordColors)])
+				}
+				fillRect(img,
+					tileX+config.BorderSize,
+					tileY+config.BorderSize,
+					innerSize,
+					innerSize,
+					innerRadius,
+					blendColors(tints))
+			}
+
+			// Highlight with a fill and a texture for color-blind readers
+			if highlighted[[2]int{y, x}] && cell != '*' {
+				if config.HighlightColor != nil {
+					fillRect(img, tileX+config.BorderSize, tileY+config.BorderSize, innerSize, innerSize, innerRadius, config.HighlightColor)
+				}
+				drawPattern(img, tileX+config.BorderSize, tileY+config.BorderSize, innerSize, innerSize, config.ShadePattern, config.GridLineColor)
+			}
+
+			// Circle sits behind the letter
+			if circled[[2]int{y, x}] && cell != '*' {
+				drawCircle(img,
+					cellX+config.CellSize/2,
+					cellY+config.CellSize/2,
+					tileSize/2-config.BorderSize-1,
+					config.GridLineColor)
+			}
 
 			// Fill black squares for blocked cells
 			if cell == '*' {
 				fillRect(img,
-					cellX+config.BorderSize,
-					cellY+config.BorderSize,
-					config.CellSize-2*config.BorderSize,
-					config.CellSize-2*config.BorderSize,
+					tileX+config.BorderSize,
+					tileY+config.BorderSize,
+					innerSize,
+					innerSize,
+					innerRadius,
 					config.BlockColor)
-			} else if cell != ' ' {
+			} else if cell != ' ' && config.ShowSolution {
 				// Draw letter
-				letter := strings.ToUpper(string(cell))
+				letter := toUpper(puzzle.cellText(y, x))
 
-				// Calculate text position (centered in cell)
-				textWidth := config.FontSize * 0.6 // Approximate width of character
+				// Calculate text position (centered in cell), rebus
+				// cells spilling evenly to both sides
+				textWidth := fontPx * 0.6 * float64(utf8.RuneCountInString(letter)) // Approximate width of characters
 				textX := float64(cellX) + (float64(config.CellSize)-textWidth)/2
 				textY := float64(cellY) + float64(config.CellSize)*0.7 // Adjust for baseline
 
 				fontContext.SetDst(img)
-				fontContext.SetClip(image.Rect(cellX, cellY, cellX+config.CellSize, cellY+config.CellSize))
+				fontContext.SetClip(image.Rect(tileX, tileY, tileX+tileSize, tileY+tileSize))
 				_, err := fontContext.DrawString(letter, freetype.Pt(int(textX), int(textY)))
 				if err != nil {
-					return err
+					return nil, err
 				}
 			}
 		}
 	}
 
 	// Add numbers for word starts
-	placements := puzzle.GetPlacements()
-	wordNumbers := make(map[string]int)
-	currentNumber := 1
-
-	for _, placement := range placements {
-		key := fmt.Sprintf("%d,%d", placement.X, placement.Y)
-		if _, exists := wordNumbers[key]; !exists {
-			wordNumbers[key] = currentNumber
-
-			// Draw number
-			numberStr := fmt.Sprintf("%d", currentNumber)
-			fontContext.SetFontSize(config.FontSize * 0.4)
-			fontContext.DrawString(numberStr,
-				freetype.Pt(
-					placement.Y*config.CellSize+config.BorderSize+2,
-					placement.X*config.CellSize+config.BorderSize+10))
-
-			currentNumber++
+	fontContext.SetClip(img.Bounds())
+	drawnNumbers := make(map[int]bool)
+	for _, placement := range puzzle.GetPlacements() {
+		if placement.Number == 0 || drawnNumbers[placement.Number] {
+			continue
 		}
+		drawnNumbers[placement.Number] = true
+
+		// Draw number
+		numberStr := fmt.Sprintf("%d", placement.Number)
+		fontContext.SetFontSize(config.FontSize * 0.4)
+		fontContext.DrawString(numberStr,
+			freetype.Pt(
+				placement.Y*config.CellSize+config.CellGap/2+config.BorderSize+int(2*scale),
+				placement.X*config.CellSize+config.CellGap/2+config.BorderSize+int(10*scale)))
 	}
 
-	// Save to file
-	f, err := os.Create(filename)
-	if err != nil {
-		return err
+	// Add the word bank
+	fontContext.SetClip(img.Bounds())
+	fontContext.SetFontSize(config.FontSize)
+	for i, word := range bankWords {
+		_, err := fontContext.DrawString(toUpper(word),
+			freetype.Pt(
+				gridWidth+config.CellSize/2,
+				config.CellSize/2+(i+1)*lineHeight))
+		if err != nil {
+			return nil, err
+		}
 	}
-	defer f.Close()
 
-	return png.Encode(f, img)
+	// Add the answer key below the solution
+	if config.ShowAnswerKey && config.ShowSolution {
+		charWidth := fontPx * 0.4 * 0.6
+		maxChars := int(float64(img.Bounds().Dx()-config.CellSize) / charWidth)
+		lines := wrapEntries(answerKeyEntries(puzzle), maxChars)
+		keyLineHeight := int(fontPx * 0.4 * 1.5)
+
+		top := img.Bounds().Dy()
+		img = extendCanvas(img, 0, len(lines)*keyLineHeight+config.CellSize/2, config.BackgroundColor)
+
+		fontContext.SetDst(img)
+		fontContext.SetClip(img.Bounds())
+		fontContext.SetFontSize(config.FontSize * 0.4)
+		for i, line := range lines {
+			_, err := fontContext.DrawString(line,
+				freetype.Pt(config.CellSize/2, top+(i+1)*keyLineHeight))
+			if err != nil {
+				return nil, err
+			}
+		}
+		fontContext.SetFontSize(config.FontSize)
+	}
+
+	// Add the footer below everything else
+	if config.Footer != "" {
+		footerPx := fontPx * 0.5
+		top := img.Bounds().Dy()
+		img = extendCanvas(img, 0, int(footerPx*2), config.BackgroundColor)
+
+		// Calculate text position (centered, approximate character width)
+		textWidth := footerPx * 0.6 * float64(utf8.RuneCountInString(config.Footer))
+		textX := (float64(img.Bounds().Dx()) - textWidth) / 2
+
+		fontContext.SetDst(img)
+		fontContext.SetClip(img.Bounds())
+		fontContext.SetFontSize(config.FontSize * 0.5)
+		_, err := fontContext.DrawString(config.Footer, freetype.Pt(int(max(textX, 0)), top+int(footerPx*1.4)))
+		if err != nil {
+			return nil, err
+		}
+		fontContext.SetFontSize(config.FontSize)
+	}
+
+	// Add the title above everything else
+	if title := puzzle.Meta().Title; config.ShowTitle && title != "" {
+		bandHeight := int(fontPx * 2)
+		img = extendCanvas(img, bandHeight, 0, config.BackgroundColor)
+
+		// Calculate text position (centered, approximate character width)
+		textWidth := fontPx * 0.6 * float64(utf8.RuneCountInString(title))
+		textX := (float64(img.Bounds().Dx()) - textWidth) / 2
+
+		fontContext.SetDst(img)
+		fontContext.SetClip(img.Bounds())
+		_, err := fontContext.DrawString(title, freetype.Pt(int(max(textX, 0)), int(fontPx*1.4)))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return img, nil
+}
+
+// Helper function to check if swapping the grid sides brings its aspect
+// ratio closer to the target
+func betterTurned(width, height int, target float64) bool {
+	aspect := float64(width) / float64(height)
+	return math.Abs(math.Log(1/aspect)-math.Log(target)) < math.Abs(math.Log(aspect)-math.Log(target))
+}
+
+// Helper function to list the answers as "1A WORD", across before down
+func answerKeyEntries(puzzle *Crossword) []string {
+	placements := append([]WordPlacement(nil), puzzle.GetPlacements()...)
+	sort.SliceStable(placements, func(i, j int) bool {
+		if placements[i].Dir != placements[j].Dir {
+			return placements[i].Dir == Horizontal
+		}
+		return placements[i].Number < placements[j].Number
+	})
+
+	entries := make([]string, len(placements))
+	for i, p := range placements {
+		entries[i] = puzzle.ClueLabel(p) + " " + p.Word
+	}
+	return entries
+}
+
+// Helper function to pack entries into lines of at most maxChars characters
+func wrapEntries(entries []string, maxChars int) []string {
+	var lines []string
+	line := ""
+	for _, entry := range entries {
+		if line != "" && utf8.RuneCountInString(line)+2+utf8.RuneCountInString(entry) > maxChars {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += "  "
+		}
+		line += entry
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// Helper function to grow the canvas above and below, keeping the drawing
+func extendCanvas(img *image.RGBA, top, bottom int, bg color.Color) *image.RGBA {
+	bounds := img.Bounds()
+	out := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()+top+bottom))
+	draw.Draw(out, out.Bounds(), &image.Uniform{bg}, image.Point{}, draw.Src)
+	draw.Draw(out, bounds.Add(image.Pt(0, top)), img, bounds.Min, draw.Src)
+	return out
 }
 
-// Helper function to draw a rectangle outline
-func drawRect(img *image.RGBA, x, y, w, h int, c color.Color) {
-	// Top
-	drawHLine(img, x, y, w, c)
-	// Bottom
-	drawHLine(img, x, y+h-1, w, c)
-	// Left
-	drawVLine(img, x, y, h, c)
-	// Right
-	drawVLine(img, x+w-1, y, h, c)
+// Helper function to draw a rectangle outline t pixels thick
+func drawRect(img *image.RGBA, x, y, w, h, r, t int, c color.Color) {
+	t = max(t, 1)
+	if r > 0 {
+		// Outline pixels are inside the shape but not inside the shape
+		// shrunk by the thickness
+		inner := max(r-t, 0)
+		for dy := 0; dy < h; dy++ {
+			for dx := 0; dx < w; dx++ {
+				if insideRounded(dx, dy, w, h, r) && !insideRounded(dx-t, dy-t, w-2*t, h-2*t, inner) {
+					img.Set(x+dx, y+dy, c)
+				}
+			}
+		}
+		return
+	}
+
+	for i := 0; i < t; i++ {
+		// Top
+		drawHLine(img, x, y+i, w, c)
+		// Bottom
+		drawHLine(img, x, y+h-1-i, w, c)
+		// Left
+		drawVLine(img, x+i, y, h, c)
+		// Right
+		drawVLine(img, x+w-1-i, y, h, c)
+	}
+}
+
+// Helper function to draw anti-aliased grid lines along the cell boundaries
+func drawGridAA(img *image.RGBA, rows, cols, cellSize int, c color.Color) {
+	bounds := img.Bounds()
+	r := vector.NewRasterizer(bounds.Dx(), bounds.Dy())
+
+	addRect := func(x0, y0, x1, y1 float32) {
+		r.MoveTo(x0, y0)
+		r.LineTo(x1, y0)
+		r.LineTo(x1, y1)
+		r.LineTo(x0, y1)
+		r.ClosePath()
+	}
+
+	// Each line is one pixel wide and straddles two pixel rows or columns
+	gridW := float32(cols*cellSize) + 1.5
+	gridH := float32(rows*cellSize) + 1.5
+	for i := 0; i <= cols; i++ {
+		x := float32(i*cellSize) + 0.5
+		addRect(x, 0.5, x+1, gridH)
+	}
+	for i := 0; i <= rows; i++ {
+		y := float32(i*cellSize) + 0.5
+		addRect(0.5, y, gridW, y+1)
+	}
+
+	r.Draw(img, bounds, image.NewUniform(c), image.Point{})
 }
 
 // Helper function to fill a rectangle
-func fillRect(img *image.RGBA, x, y, w, h int, c color.Color) {
+func fillRect(img *image.RGBA, x, y, w, h, r int, c color.Color) {
+	for dy := 0; dy < h; dy++ {
+		for dx := 0; dx < w; dx++ {
+			if r <= 0 || insideRounded(dx, dy, w, h, r) {
+				img.Set(x+dx, y+dy, c)
+			}
+		}
+	}
+}
+
+// Helper function to check if a pixel offset lies inside a w by h
+// rectangle with corners rounded by radius r
+func insideRounded(dx, dy, w, h, r int) bool {
+	if dx < 0 || dy < 0 || dx >= w || dy >= h {
+		return false
+	}
+
+	r = min(r, w/2, h/2)
+	cx := min(max(dx, r), w-1-r)
+	cy := min(max(dy, r), h-1-r)
+	return (dx-cx)*(dx-cx)+(dy-cy)*(dy-cy) <= r*r
+}
+
+// Helper function to average several colors
+func blendColors(colors []color.Color) color.Color {
+	var r, g, b, a uint32
+	for _, c := range colors {
+		cr, cg, cb, ca := c.RGBA()
+		r, g, b, a = r+cr, g+cg, b+cb, a+ca
+	}
+	n := uint32(len(colors))
+	return color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)}
+}
+
+// Helper function to texture a rectangle with a shade pattern
+func drawPattern(img *image.RGBA, x, y, w, h int, pattern ShadePattern, c color.Color) {
 	for dy := 0; dy < h; dy++ {
 		for dx := 0; dx < w; dx++ {
-			img.Set(x+dx, y+dy, c)
+			switch {
+			case pattern == PatternDots && dx%4 == 1 && dy%4 == 1:
+				img.Set(x+dx, y+dy, c)
+			case pattern == PatternDiagonal && (dx+dy)%6 == 0:
+				img.Set(x+dx, y+dy, c)
+			}
+		}
+	}
+}
+
+// Helper function to swap the coordinates of cells for a transposed grid
+func swapCells(cells [][2]int) [][2]int {
+	swapped := make([][2]int, len(cells))
+	for i, cell := range cells {
+		swapped[i] = [2]int{cell[1], cell[0]}
+	}
+	return swapped
+}
+
+// Helper function to draw a one pixel wide circle outline
+func drawCircle(img *image.RGBA, cx, cy, r int, c color.Color) {
+	for dy := -r - 1; dy <= r+1; dy++ {
+		for dx := -r - 1; dx <= r+1; dx++ {
+			d := math.Hypot(float64(dx), float64(dy))
+			if math.Abs(d-float64(r)) <= 0.5 {
+				img.Set(cx+dx, cy+dy, c)
+			}
 		}
 	}
 }
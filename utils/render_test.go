@@ -0,0 +1,859 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/golang/freetype"
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// letterRed is a letter color no other part of a default render uses
+var letterRed = color.RGBA{R: 255, A: 255}
+
+// countColor counts the pixels of the given color inside rect
+func countColor(img *image.RGBA, rect image.Rectangle, c color.Color) int {
+	r0, g0, b0, a0 := c.RGBA()
+	count := 0
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			r, g, b, a := img.At(x, y).RGBA()
+			if r == r0 && g == g0 && b == b0 && a == a0 {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// cellRect returns the pixel area of a cell, as {X, Y} board coordinates
+func cellRect(config RenderConfig, x, y int) image.Rectangle {
+	return image.Rect(y*config.CellSize, x*config.CellSize, (y+1)*config.CellSize, (x+1)*config.CellSize)
+}
+
+func TestRenderPuzzleImage(t *testing.T) {
+	puzzle := crossingPuzzle()
+	config := DefaultConfig()
+	config.LetterColor = letterRed
+
+	img, err := RenderPuzzleImage(puzzle, config)
+	if err != nil {
+		t.Fatalf("RenderPuzzleImage() error = %v", err)
+	}
+
+	size := 6*config.CellSize + config.BorderSize
+	if got := img.Bounds(); got != image.Rect(0, 0, size, size) {
+		t.Fatalf("bounds = %v, want %dx%d", got, size, size)
+	}
+
+	tests := []struct {
+		name   string
+		x, y   int
+		letter bool
+	}{
+		{"shared C", 1, 1, true},
+		{"across S", 1, 3, true},
+		{"down E", 4, 1, true},
+		{"empty", 4, 4, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := countColor(img, cellRect(config, tt.x, tt.y), letterRed)
+			if (n > 0) != tt.letter {
+				t.Errorf("cell (%d,%d) has %d letter pixels, want letter = %v", tt.x, tt.y, n, tt.letter)
+			}
+		})
+	}
+}
+
+func TestRenderPuzzleToJPEG(t *testing.T) {
+	puzzle := crossingPuzzle()
+	config := DefaultConfig()
+
+	for _, quality := range []int{1, 75, 100} {
+		t.Run(fmt.Sprintf("quality %d", quality), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "puzzle.jpg")
+			if err := RenderPuzzleToJPEG(puzzle, path, quality, config); err != nil {
+				t.Fatalf("RenderPuzzleToJPEG() error = %v", err)
+			}
+
+			f, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			img, err := jpeg.Decode(f)
+			if err != nil {
+				t.Fatalf("output is not a valid JPEG: %v", err)
+			}
+			size := 6*config.CellSize + config.BorderSize
+			if got := img.Bounds(); got != image.Rect(0, 0, size, size) {
+				t.Errorf("bounds = %v, want %dx%d", got, size, size)
+			}
+		})
+	}
+}
+
+func TestRenderFontBytes(t *testing.T) {
+	tests := []struct {
+		name    string
+		font    []byte
+		wantErr bool
+	}{
+		{"default", nil, false},
+		{"bold", gobold.TTF, false},
+		{"mono", gomono.TTF, false},
+		{"invalid", []byte("not a font"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.FontBytes = tt.font
+			config.LetterColor = letterRed
+
+			img, err := RenderPuzzleImage(crossingPuzzle(), config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RenderPuzzleImage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && countColor(img, cellRect(config, 1, 2), letterRed) == 0 {
+				t.Error("no letter drawn")
+			}
+		})
+	}
+}
+
+func TestRenderAntiAlias(t *testing.T) {
+	tests := []struct {
+		name      string
+		antiAlias bool
+		wantGray  bool
+	}{
+		{"aliased", false, false},
+		{"anti-aliased", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.AntiAlias = tt.antiAlias
+
+			// An empty grid draws nothing but lines
+			img, err := RenderPuzzleImage(NewCrossword(3, 3), config)
+			if err != nil {
+				t.Fatalf("RenderPuzzleImage() error = %v", err)
+			}
+
+			bounds := img.Bounds()
+			gray := bounds.Dx()*bounds.Dy() -
+				countColor(img, bounds, color.White) - countColor(img, bounds, color.Black)
+			if (gray > 0) != tt.wantGray {
+				t.Errorf("%d intermediate pixels, want some = %v", gray, tt.wantGray)
+			}
+		})
+	}
+}
+
+// decodePNG reads a PNG file written by a render
+func decodePNG(t *testing.T, path string) image.Image {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("%s is not a valid PNG: %v", path, err)
+	}
+	return img
+}
+
+func TestRenderPuzzlePair(t *testing.T) {
+	dir := t.TempDir()
+	blankPath := filepath.Join(dir, "blank.png")
+	solutionPath := filepath.Join(dir, "solution.png")
+
+	config := DefaultConfig()
+	config.LetterColor = letterRed
+	if err := RenderPuzzlePair(crossingPuzzle(), blankPath, solutionPath, config); err != nil {
+		t.Fatalf("RenderPuzzlePair() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		letters bool
+	}{
+		{"blank", blankPath, false},
+		{"solution", solutionPath, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := decodePNG(t, tt.path)
+			rgba := image.NewRGBA(img.Bounds())
+			draw.Draw(rgba, rgba.Bounds(), img, image.Point{}, draw.Src)
+
+			n := countColor(rgba, cellRect(config, 1, 3), letterRed)
+			if (n > 0) != tt.letters {
+				t.Errorf("%d letter pixels, want letters = %v", n, tt.letters)
+			}
+		})
+	}
+}
+
+func TestRenderLanguage(t *testing.T) {
+	render := func(rows []string, lang string) *image.RGBA {
+		t.Helper()
+		puzzle, err := CrosswordFromGrid(rows)
+		if err != nil {
+			t.Fatal(err)
+		}
+		config := DefaultConfig()
+		config.Language = lang
+		img, err := RenderPuzzleImage(puzzle, config)
+		if err != nil {
+			t.Fatalf("RenderPuzzleImage() error = %v", err)
+		}
+		return img
+	}
+
+	tests := []struct {
+		name string
+		lang string
+		same []string // Uppercase grid drawing the same glyphs
+	}{
+		{"default", "", []string{"IKI"}},
+		{"english", "en", []string{"IKI"}},
+		{"turkish", "tr", []string{"İKİ"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := render([]string{"iki"}, tt.lang)
+			if want := render(tt.same, ""); !bytes.Equal(got.Pix, want.Pix) {
+				t.Errorf("render differs from %v", tt.same)
+			}
+		})
+	}
+
+	if bytes.Equal(render([]string{"iki"}, "tr").Pix, render([]string{"iki"}, "").Pix) {
+		t.Error("dotted capital I drawn like the plain one")
+	}
+
+	config := DefaultConfig()
+	config.Language = "not a tag!"
+	if _, err := RenderPuzzleImage(crossingPuzzle(), config); err == nil {
+		t.Error("invalid language accepted")
+	}
+}
+
+func TestRenderCircledCells(t *testing.T) {
+	config := DefaultConfig()
+	config.ShowSolution = false
+	config.CircledCells = [][2]int{{1, 2}, {3, 1}}
+
+	img, err := RenderPuzzleImage(crossingPuzzle(), config)
+	if err != nil {
+		t.Fatalf("RenderPuzzleImage() error = %v", err)
+	}
+
+	// Topmost and leftmost points of the inscribed circle
+	r := config.CellSize/2 - config.BorderSize - 1
+	onCircle := func(x, y int) []image.Point {
+		cx, cy := y*config.CellSize+config.CellSize/2, x*config.CellSize+config.CellSize/2
+		return []image.Point{{cx, cy - r}, {cx - r, cy}}
+	}
+
+	tests := []struct {
+		name    string
+		x, y    int
+		circled bool
+	}{
+		{"circled across letter", 1, 2, true},
+		{"circled down letter", 3, 1, true},
+		{"plain letter", 1, 3, false},
+		{"empty", 4, 4, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, p := range onCircle(tt.x, tt.y) {
+				drawn := img.At(p.X, p.Y) == color.RGBA{A: 255}
+				if drawn != tt.circled {
+					t.Errorf("pixel %v drawn = %v, want %v", p, drawn, tt.circled)
+				}
+			}
+		})
+	}
+}
+
+// drawText draws text the way the renderer does, onto a blank image of the
+// given size with its baseline starting at (x, y)
+func drawText(t *testing.T, bounds image.Rectangle, config RenderConfig, size float64, text string, x, y int) *image.RGBA {
+	t.Helper()
+	img := image.NewRGBA(bounds)
+	draw.Draw(img, bounds, &image.Uniform{config.BackgroundColor}, image.Point{}, draw.Src)
+
+	font, err := truetype.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := freetype.NewContext()
+	ctx.SetDPI(72)
+	ctx.SetFont(font)
+	ctx.SetFontSize(size)
+	ctx.SetClip(bounds)
+	ctx.SetDst(img)
+	ctx.SetSrc(image.NewUniform(config.LetterColor))
+	if _, err := ctx.DrawString(text, freetype.Pt(x, y)); err != nil {
+		t.Fatal(err)
+	}
+	return img
+}
+
+// samePixels reports whether two images match inside rect
+func samePixels(a, b *image.RGBA, rect image.Rectangle) bool {
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			if a.RGBAAt(x, y) != b.RGBAAt(x, y) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestRenderWordBank(t *testing.T) {
+	puzzle := crossingPuzzle()
+	config := DefaultConfig()
+
+	plain, err := RenderPuzzleImage(puzzle, config)
+	if err != nil {
+		t.Fatalf("RenderPuzzleImage() error = %v", err)
+	}
+	config.ShowWordBank = true
+	img, err := RenderPuzzleImage(puzzle, config)
+	if err != nil {
+		t.Fatalf("RenderPuzzleImage() error = %v", err)
+	}
+
+	if img.Bounds().Dx() <= plain.Bounds().Dx() {
+		t.Fatalf("width %d with the word bank, %d without", img.Bounds().Dx(), plain.Bounds().Dx())
+	}
+
+	// Match every line of the shuffled bank against each placed word
+	gridWidth := plain.Bounds().Dx()
+	lineHeight := int(config.FontSize * 1.2)
+	var found []string
+	for i := range puzzle.GetPlacements() {
+		baseline := config.CellSize/2 + (i+1)*lineHeight
+		band := image.Rect(gridWidth, baseline-lineHeight+1, img.Bounds().Dx(), baseline+1)
+		for _, p := range puzzle.GetPlacements() {
+			want := drawText(t, img.Bounds(), config, config.FontSize, p.Word, gridWidth+config.CellSize/2, baseline)
+			if samePixels(img, want, band) {
+				found = append(found, p.Word)
+				break
+			}
+		}
+	}
+
+	slices.Sort(found)
+	if want := []string{"CANE", "CASA"}; !slices.Equal(found, want) {
+		t.Errorf("word bank shows %v, want %v", found, want)
+	}
+}
+
+func TestRenderWordColors(t *testing.T) {
+	red, blue := color.RGBA{R: 255, A: 255}, color.RGBA{B: 255, A: 255}
+	c := crossingPuzzle()
+	c.putWord("SOLE", 1, 3, Vertical)
+	c.AssignNumbers()
+
+	config := DefaultConfig()
+	config.ShowSolution = false
+	config.WordColors = []color.Color{red, blue}
+	img, err := RenderPuzzleImage(c, config)
+	if err != nil {
+		t.Fatalf("RenderPuzzleImage() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		x, y int
+		want color.Color
+	}{
+		{"word 1", 1, 2, red},
+		{"word 2", 3, 3, blue},
+		{"crossing of 1 and 2", 1, 3, blendColors([]color.Color{red, blue})},
+		{"empty", 4, 4, color.White},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y := tt.y*config.CellSize+config.CellSize/2, tt.x*config.CellSize+config.CellSize/2
+			if got, want := img.RGBAAt(x, y), color.RGBAModel.Convert(tt.want); got != want {
+				t.Errorf("cell color = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestRenderFitAspect(t *testing.T) {
+	// A tall grid holding a single down word
+	puzzle := NewCrossword(3, 8)
+	puzzle.putWord("GATTO", 1, 1, Vertical)
+	puzzle.AssignNumbers()
+
+	tests := []struct {
+		name   string
+		aspect float64
+		wide   bool
+	}{
+		{"no target", 0, false},
+		{"tall page", 0.7, false},
+		{"wide page", 1.5, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.LetterColor = letterRed
+			config.FitAspect = tt.aspect
+			img, err := RenderPuzzleImage(puzzle, config)
+			if err != nil {
+				t.Fatalf("RenderPuzzleImage() error = %v", err)
+			}
+
+			bounds := img.Bounds()
+			if wide := bounds.Dx() > bounds.Dy(); wide != tt.wide {
+				t.Fatalf("image is %dx%d, want wider = %v", bounds.Dx(), bounds.Dy(), tt.wide)
+			}
+
+			// Turned, the down word reads across the second row
+			for i := 0; i < 5; i++ {
+				x, y := 1+i, 1
+				if tt.wide {
+					x, y = y, x
+				}
+				if countColor(img, cellRect(config, x, y), letterRed) == 0 {
+					t.Errorf("letter %d missing from cell (%d,%d)", i, x, y)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderShadePattern(t *testing.T) {
+	blue := color.RGBA{B: 255, A: 255}
+	// Pixels of a pattern over the 36 pixel inside of a default cell
+	diagonal := 0
+	for dy := 0; dy < 36; dy++ {
+		for dx := 0; dx < 36; dx++ {
+			if (dx+dy)%6 == 0 {
+				diagonal++
+			}
+		}
+	}
+
+	tests := []struct {
+		name    string
+		pattern ShadePattern
+		x, y    int
+		want    int
+	}{
+		{"none", PatternNone, 4, 4, 0},
+		{"dots on an empty cell", PatternDots, 4, 4, 9 * 9},
+		{"dots on a letter cell", PatternDots, 1, 2, 9 * 9},
+		{"diagonal", PatternDiagonal, 4, 4, diagonal},
+		{"not highlighted", PatternDots, 3, 4, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.ShowSolution = false
+			config.GridLineColor = blue
+			config.ShadePattern = tt.pattern
+			config.HighlightCells = [][2]int{{4, 4}, {1, 2}}
+			img, err := RenderPuzzleImage(crossingPuzzle(), config)
+			if err != nil {
+				t.Fatalf("RenderPuzzleImage() error = %v", err)
+			}
+
+			inside := cellRect(config, tt.x, tt.y).Inset(config.BorderSize)
+			if got := countColor(img, inside, blue); got != tt.want {
+				t.Errorf("%d pattern pixels inside cell (%d,%d), want %d", got, tt.x, tt.y, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderAnswerKey(t *testing.T) {
+	puzzle := crossingPuzzle()
+	if got, want := answerKeyEntries(puzzle), []string{"1A CASA", "1D CANE"}; !slices.Equal(got, want) {
+		t.Fatalf("answerKeyEntries() = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		name         string
+		showKey      bool
+		showSolution bool
+		key          bool
+	}{
+		{"off", false, true, false},
+		{"solution", true, true, true},
+		{"blank grid", true, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.ShowAnswerKey = tt.showKey
+			config.ShowSolution = tt.showSolution
+			img, err := RenderPuzzleImage(puzzle, config)
+			if err != nil {
+				t.Fatalf("RenderPuzzleImage() error = %v", err)
+			}
+
+			gridSize := 6*config.CellSize + config.BorderSize
+			if got := img.Bounds().Dy() > gridSize; got != tt.key {
+				t.Fatalf("height %d for a %d pixel grid, want a key = %v", img.Bounds().Dy(), gridSize, tt.key)
+			}
+			if !tt.key {
+				return
+			}
+
+			// Both entries fit on one line below the grid
+			lineHeight := int(config.FontSize * 0.4 * 1.5)
+			want := drawText(t, img.Bounds(), config, config.FontSize*0.4, "1A CASA  1D CANE", config.CellSize/2, gridSize+lineHeight)
+			band := image.Rect(0, gridSize, img.Bounds().Dx(), img.Bounds().Dy())
+			if !samePixels(img, want, band) {
+				t.Error("answer key does not read \"1A CASA  1D CANE\"")
+			}
+		})
+	}
+}
+
+func TestRenderCellGap(t *testing.T) {
+	tests := []struct {
+		name string
+		gap  int
+	}{
+		{"shared borders", 0},
+		{"gap", 4},
+		{"wide gap", 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.CellGap = tt.gap
+			img, err := RenderPuzzleImage(crossingPuzzle(), config)
+			if err != nil {
+				t.Fatalf("RenderPuzzleImage() error = %v", err)
+			}
+
+			// The strip between cells (4,4) and (4,5), and between (4,4) and (5,4)
+			edge := 5 * config.CellSize
+			middle := 4*config.CellSize + config.CellSize/2
+			between := []image.Rectangle{
+				image.Rect(edge-tt.gap/2, middle, edge+tt.gap/2, middle+1),
+				image.Rect(middle, edge-tt.gap/2, middle+1, edge+tt.gap/2),
+			}
+			for _, strip := range between {
+				if got := countColor(img, strip, color.White); got != strip.Dx()*strip.Dy() {
+					t.Errorf("%d of %d background pixels in %v", got, strip.Dx()*strip.Dy(), strip)
+				}
+			}
+			if tt.gap == 0 && img.RGBAAt(edge, middle) != (color.RGBA{A: 255}) {
+				t.Errorf("no shared border at (%d,%d)", edge, middle)
+			}
+
+			// The block at (1,0) is filled inside its tile only
+			block := cellRect(config, 1, 0)
+			tile := block.Inset(tt.gap / 2)
+			if got, want := countColor(img, block, color.White)-countColor(img, tile, color.White), block.Dx()*block.Dy()-tile.Dx()*tile.Dy(); got != want {
+				t.Errorf("%d background pixels around the block tile, want %d", got, want)
+			}
+			inside := tile.Inset(config.BorderSize)
+			if got, want := countColor(img, inside, color.Black), inside.Dx()*inside.Dy(); got != want {
+				t.Errorf("%d block pixels inside the tile, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestRenderBorderScale(t *testing.T) {
+	tests := []struct {
+		name       string
+		cellSize   int
+		borderSize int
+		scale      float64
+		radius     int
+		want       int
+	}{
+		{"default border", 40, 2, 0, 0, 1},
+		{"wide border", 40, 6, 0, 0, 1},
+		{"scaled", 40, 2, 0.1, 0, 4},
+		{"scale overrides border", 40, 6, 0.1, 0, 4},
+		{"scaled large cells", 80, 2, 0.1, 0, 8},
+		{"scaled rounded", 80, 2, 0.1, 16, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.CellSize = tt.cellSize
+			config.BorderSize = tt.borderSize
+			config.BorderScale = tt.scale
+			config.CornerRadius = tt.radius
+			img, err := RenderPuzzleImage(crossingPuzzle(), config)
+			if err != nil {
+				t.Fatalf("RenderPuzzleImage() error = %v", err)
+			}
+
+			// Each side of the unused cell (4,4) is outlined as thick as the
+			// scaled border, 1px otherwise
+			rect := cellRect(config, 4, 4)
+			middle := rect.Min.Add(image.Pt(tt.cellSize/2, tt.cellSize/2))
+			sides := []image.Rectangle{
+				image.Rect(rect.Min.X, middle.Y, middle.X, middle.Y+1),
+				image.Rect(middle.X, rect.Max.Y-tt.cellSize/2, middle.X+1, rect.Max.Y),
+				image.Rect(middle.X, rect.Min.Y, middle.X+1, middle.Y),
+				image.Rect(middle.X, middle.Y, rect.Max.X, middle.Y+1),
+			}
+			for _, side := range sides {
+				if got := countColor(img, side, color.Black); got != tt.want {
+					t.Errorf("border %v is %d pixels thick, want %d", side, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderCornerRadius(t *testing.T) {
+	tests := []struct {
+		name     string
+		cellSize int
+		radius   int
+		gap      int
+		x, y     int
+		block    bool
+	}{
+		{"letter cell", 40, 8, 0, 1, 2, false},
+		{"block", 40, 8, 0, 1, 0, true},
+		{"unused cell", 40, 8, 0, 4, 4, false},
+		{"large radius", 60, 20, 0, 1, 3, false},
+		{"block with gap", 40, 8, 4, 1, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.CellSize = tt.cellSize
+			config.CornerRadius = tt.radius
+			config.CellGap = tt.gap
+			img, err := RenderPuzzleImage(crossingPuzzle(), config)
+			if err != nil {
+				t.Fatalf("RenderPuzzleImage() error = %v", err)
+			}
+
+			tile := cellRect(config, tt.x, tt.y).Inset(tt.gap / 2)
+			white, black := color.RGBA{255, 255, 255, 255}, color.RGBA{A: 255}
+			corners := []image.Point{
+				tile.Min,
+				{tile.Max.X - 1, tile.Min.Y},
+				{tile.Min.X, tile.Max.Y - 1},
+				{tile.Max.X - 1, tile.Max.Y - 1},
+			}
+			for _, p := range corners {
+				if got := img.RGBAAt(p.X, p.Y); got != white {
+					t.Errorf("corner pixel %v = %v, want background", p, got)
+				}
+			}
+
+			middle := tile.Min.Add(image.Pt(tile.Dx()/2, tile.Dy()/2))
+			edges := []image.Point{
+				{middle.X, tile.Min.Y},
+				{middle.X, tile.Max.Y - 1},
+				{tile.Min.X, middle.Y},
+				{tile.Max.X - 1, middle.Y},
+			}
+			for _, p := range edges {
+				if got := img.RGBAAt(p.X, p.Y); got != black {
+					t.Errorf("edge pixel %v = %v, want the grid line", p, got)
+				}
+			}
+
+			// Block fills are rounded inside the border too
+			if tt.block {
+				inside := tile.Inset(config.BorderSize)
+				if got := img.RGBAAt(inside.Min.X, inside.Min.Y); got != white {
+					t.Errorf("block fill corner %v = %v, want background", inside.Min, got)
+				}
+				if got := img.RGBAAt(middle.X, middle.Y); got != black {
+					t.Errorf("block center %v = %v, want filled", middle, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderEmptyCellColor(t *testing.T) {
+	gray := color.RGBA{R: 220, G: 220, B: 220, A: 255}
+
+	tests := []struct {
+		name         string
+		fill         color.Color
+		showSolution bool
+		x, y         int
+		want         color.Color
+	}{
+		{"fillable cell", gray, false, 1, 2, gray},
+		{"crossing cell", gray, false, 3, 1, gray},
+		{"block", gray, false, 1, 0, color.Black},
+		{"unused cell", gray, false, 4, 4, color.White},
+		{"no fill", nil, false, 1, 2, color.White},
+		{"solution", gray, true, 1, 2, color.White},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.ShowSolution = tt.showSolution
+			config.EmptyCellColor = tt.fill
+			img, err := RenderPuzzleImage(crossingPuzzle(), config)
+			if err != nil {
+				t.Fatalf("RenderPuzzleImage() error = %v", err)
+			}
+
+			// A pixel inside the cell, clear of its letter
+			inside := cellRect(config, tt.x, tt.y).Inset(config.BorderSize).Min
+			if got, want := img.RGBAAt(inside.X, inside.Y), color.RGBAModel.Convert(tt.want); got != want {
+				t.Errorf("cell (%d,%d) = %v, want %v", tt.x, tt.y, got, want)
+			}
+		})
+	}
+}
+
+func TestRenderDPI(t *testing.T) {
+	// glyphHeight measures the rows holding letter pixels in a cell
+	glyphHeight := func(img *image.RGBA, rect image.Rectangle) int {
+		top, bottom := rect.Max.Y, rect.Min.Y
+		for y := rect.Min.Y; y < rect.Max.Y; y++ {
+			if countColor(img, image.Rect(rect.Min.X, y, rect.Max.X, y+1), letterRed) > 0 {
+				top, bottom = min(top, y), max(bottom, y+1)
+			}
+		}
+		return max(bottom-top, 0)
+	}
+
+	base := DefaultConfig()
+	base.LetterColor = letterRed
+	plain, err := RenderPuzzleImage(crossingPuzzle(), base)
+	if err != nil {
+		t.Fatalf("RenderPuzzleImage() error = %v", err)
+	}
+	plainGlyph := glyphHeight(plain, cellRect(base, 4, 1))
+	if plainGlyph == 0 {
+		t.Fatal("no letter drawn at 72 DPI")
+	}
+
+	tests := []struct {
+		name     string
+		dpi      float64
+		cellSize int
+		border   int
+	}{
+		{"default", 0, 40, 2},
+		{"screen", 72, 40, 2},
+		{"double", 144, 80, 4},
+		{"print", 300, 167, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := base
+			config.DPI = tt.dpi
+			img, err := RenderPuzzleImage(crossingPuzzle(), config)
+			if err != nil {
+				t.Fatalf("RenderPuzzleImage() error = %v", err)
+			}
+
+			size := 6*tt.cellSize + tt.border
+			if got := img.Bounds(); got != image.Rect(0, 0, size, size) {
+				t.Fatalf("bounds = %v, want %dx%d", got, size, size)
+			}
+
+			// Letters grow with the resolution like the cells
+			scaled := config
+			scaled.CellSize = tt.cellSize
+			scale := float64(tt.cellSize) / float64(base.CellSize)
+			got := glyphHeight(img, cellRect(scaled, 4, 1))
+			if want := float64(plainGlyph) * scale; math.Abs(float64(got)-want) > want*0.1 {
+				t.Errorf("letter is %d pixels high, want about %.0f", got, want)
+			}
+		})
+	}
+}
+
+func TestRenderFooter(t *testing.T) {
+	tests := []struct {
+		name   string
+		footer string
+	}{
+		{"none", ""},
+		{"copyright", "© site.com"},
+		{"wider than the grid", "Cruciverba generato automaticamente, tutti i diritti riservati"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.LetterColor = letterRed
+			config.Footer = tt.footer
+			img, err := RenderPuzzleImage(crossingPuzzle(), config)
+			if err != nil {
+				t.Fatalf("RenderPuzzleImage() error = %v", err)
+			}
+
+			gridSize := 6*config.CellSize + config.BorderSize
+			footerPx := config.FontSize * 0.5
+			wantHeight := gridSize
+			if tt.footer != "" {
+				wantHeight += int(footerPx * 2)
+			}
+			if got := img.Bounds(); got != image.Rect(0, 0, gridSize, wantHeight) {
+				t.Fatalf("bounds = %v, want %dx%d", got, gridSize, wantHeight)
+			}
+			if tt.footer == "" {
+				return
+			}
+
+			// The footer is centered, clipped when wider than the image
+			band := image.Rect(0, gridSize, gridSize, wantHeight)
+			if countColor(img, band, letterRed) == 0 {
+				t.Fatal("no footer glyphs below the grid")
+			}
+			textX := (float64(gridSize) - footerPx*0.6*float64(len([]rune(tt.footer)))) / 2
+			want := drawText(t, img.Bounds(), config, footerPx, tt.footer, int(max(textX, 0)), gridSize+int(footerPx*1.4))
+			if !samePixels(img, want, band) {
+				t.Errorf("footer does not read %q", tt.footer)
+			}
+		})
+	}
+}
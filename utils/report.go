@@ -0,0 +1,94 @@
+package utils
+
+// PuzzleReport summarizes the quality metrics of a grid
+type PuzzleReport struct {
+	Words         int           // Number of placed words
+	Across        int           // Number of across words
+	Down          int           // Number of down words
+	Intersections int           // Cells shared by an across and a down word
+	Density       float64       // Ratio of letter cells to all cells
+	Longest       WordPlacement // Longest placed word
+	Shortest      WordPlacement // Shortest placed word
+	BlockRatio    float64       // Ratio of block cells to all cells
+	Connected     bool          // Whether every word can be reached through crossings
+}
+
+// Report aggregates the grid metrics editors review before publishing
+func (c *Crossword) Report() PuzzleReport {
+	report := PuzzleReport{
+		Words:      len(c.placements),
+		BlockRatio: c.BlockRatio(),
+		Connected:  c.connected(),
+	}
+	report.Across, report.Down = c.CountByDirection()
+	report.Longest, _ = c.LongestWord()
+	report.Shortest = c.shortestWord()
+
+	letters := len(c.FillableCells())
+	if c.width > 0 && c.height > 0 {
+		report.Density = float64(letters) / float64(c.width*c.height)
+	}
+
+	for x := range c.board {
+		for y := range c.board[x] {
+			if c.hWords[x][y] > 0 && c.vWords[x][y] > 0 {
+				report.Intersections++
+			}
+		}
+	}
+
+	return report
+}
+
+// UncheckedCells returns the letter cells belonging to a word in only one
+// direction, in reading order
+func (c *Crossword) UncheckedCells() [][2]int {
+	var cells [][2]int
+	for x := range c.board {
+		for y, cell := range c.board[x] {
+			if isLetter(cell) && (c.hWords[x][y] == 0) != (c.vWords[x][y] == 0) {
+				cells = append(cells, [2]int{x, y})
+			}
+		}
+	}
+	return cells
+}
+
+// shortestWord returns the placement with the smallest length, the first
+// one on ties
+func (c *Crossword) shortestWord() WordPlacement {
+	var shortest WordPlacement
+	for i, p := range c.placements {
+		if i == 0 || p.Length < shortest.Length {
+			shortest = p
+		}
+	}
+	return shortest
+}
+
+// connected checks if all letter cells form a single group of neighbors.
+// Neighboring letters always share a word, so this matches the words
+// being linked through crossings. An empty board is not connected.
+func (c *Crossword) connected() bool {
+	cells := c.FillableCells()
+	if len(cells) == 0 {
+		return false
+	}
+
+	seen := map[[2]int]bool{cells[0]: true}
+	queue := [][2]int{cells[0]}
+	directions := [][2]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}}
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+		for _, d := range directions {
+			next := [2]int{cell[0] + d[0], cell[1] + d[1]}
+			if c.isValidPosition(next[0], next[1]) && isLetter(c.board[next[0]][next[1]]) && !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return len(seen) == len(cells)
+}
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestReport(t *testing.T) {
+	disconnected := func() *Crossword {
+		c, err := CrosswordFromGrid([]string{
+			"GATTO",
+			".....",
+			"LUPO#",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		puzzle   func() *Crossword
+		want     PuzzleReport
+		longest  string
+		shortest string
+	}{
+		{
+			name:   "crossing",
+			puzzle: crossingPuzzle,
+			want: PuzzleReport{
+				Words: 2, Across: 1, Down: 1, Intersections: 1,
+				Density: 7.0 / 36, BlockRatio: 4.0 / 36, Connected: true,
+			},
+			longest:  "CASA",
+			shortest: "CASA",
+		},
+		{
+			name:   "disconnected",
+			puzzle: disconnected,
+			want: PuzzleReport{
+				Words: 2, Across: 2, Down: 0, Intersections: 0,
+				Density: 9.0 / 15, BlockRatio: 1.0 / 15, Connected: false,
+			},
+			longest:  "GATTO",
+			shortest: "LUPO",
+		},
+		{
+			name:   "empty",
+			puzzle: func() *Crossword { return NewCrossword(3, 3) },
+			want:   PuzzleReport{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.puzzle().Report()
+			if got.Longest.Word != tt.longest || got.Shortest.Word != tt.shortest {
+				t.Errorf("longest %q and shortest %q, want %q and %q", got.Longest.Word, got.Shortest.Word, tt.longest, tt.shortest)
+			}
+
+			got.Longest, got.Shortest = WordPlacement{}, WordPlacement{}
+			if got != tt.want {
+				t.Errorf("Report() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUncheckedCells(t *testing.T) {
+	grid := func(rows ...string) func() *Crossword {
+		return func() *Crossword {
+			c, err := CrosswordFromGrid(rows)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return c
+		}
+	}
+
+	tests := []struct {
+		name   string
+		puzzle func() *Crossword
+		want   [][2]int
+	}{
+		{"crossing", crossingPuzzle, [][2]int{{1, 2}, {1, 3}, {1, 4}, {2, 1}, {3, 1}, {4, 1}}},
+		{"fully checked", grid("OR", "RE"), nil},
+		{"one unchecked", grid("ORA", "RE."), [][2]int{{0, 2}}},
+		{"empty", func() *Crossword { return NewCrossword(3, 3) }, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.puzzle().UncheckedCells(); !slices.Equal(got, tt.want) {
+				t.Errorf("UncheckedCells() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,329 @@
+package utils
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// spiralOrder is the spiral scan order of one grid size
+type spiralOrder struct {
+	width, height int
+	cells         [][2]int // cells in scan order
+	rank          []int    // scan position of each cell, in row-major order
+}
+
+// spiral returns the spiral scan order of the board, built once per grid size
+func (c *Crossword) spiral() *spiralOrder {
+	if s := &c.spiralScan; s.cells != nil && s.width == c.width && s.height == c.height {
+		return s
+	}
+
+	cells := spiralCells(c.width, c.height)
+	rank := make([]int, len(cells))
+	for i, cell := range cells {
+		rank[cell[0]*c.width+cell[1]] = i
+	}
+	c.spiralScan = spiralOrder{width: c.width, height: c.height, cells: cells, rank: rank}
+	return &c.spiralScan
+}
+
+// spiralCells walks a square spiral outward from the center of the board,
+// keeping the cells that fall inside it
+func spiralCells(width, height int) [][2]int {
+	total := width * height
+	cells := make([][2]int, 0, total)
+
+	x, y := (height-1)/2, (width-1)/2
+	directions := [][2]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}}
+	steps, d := 1, 0
+
+	add := func() {
+		if x >= 0 && x < height && y >= 0 && y < width {
+			cells = append(cells, [2]int{x, y})
+		}
+	}
+
+	add()
+	for len(cells) < total {
+		// Each side length is walked twice before growing
+		for side := 0; side < 2 && len(cells) < total; side++ {
+			for i := 0; i < steps; i++ {
+				x += directions[d][0]
+				y += directions[d][1]
+				add()
+			}
+			d = (d + 1) % 4
+		}
+		steps++
+	}
+
+	return cells
+}
+
+// fewestDeadEnds keeps the positions that would seal off the fewest empty
+// cells, preserving their order
+func (c *Crossword) fewestDeadEnds(word string, positions []Position) []Position {
+	var kept []Position
+	fewest := -1
+	for _, p := range positions {
+		dead := c.newDeadEnds(word, p.X, p.Y, p.Dir)
+		if fewest < 0 || dead < fewest {
+			fewest = dead
+			kept = kept[:0]
+		}
+		if dead == fewest {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+// newDeadEnds counts the empty cells around a candidate placement that it
+// would leave boxed in by blocks along both axes, with no room for a word
+func (c *Crossword) newDeadEnds(word string, x, y int, dir Direction) int {
+	p := WordPlacement{X: x, Y: y, Dir: dir, Length: len(word)}
+
+	// The cells the placement would write
+	overlay := make(map[[2]int]rune, len(word)+2)
+	for i := 0; i < len(word); i++ {
+		x1, y1 := p.cell(i)
+		overlay[[2]int{x1, y1}] = rune(word[i])
+	}
+	for _, i := range []int{-1, len(word)} {
+		if x1, y1 := p.cell(i); c.isValidPosition(x1, y1) {
+			overlay[[2]int{x1, y1}] = '*'
+		}
+	}
+
+	at := func(x, y int, placed bool) rune {
+		if !c.isValidPosition(x, y) {
+			return '*'
+		}
+		if r, ok := overlay[[2]int{x, y}]; ok && placed {
+			return r
+		}
+		return c.board[x][y]
+	}
+	dead := func(x, y int, placed bool) bool {
+		return at(x, y, placed) == ' ' &&
+			at(x, y-1, placed) == '*' && at(x, y+1, placed) == '*' &&
+			at(x-1, y, placed) == '*' && at(x+1, y, placed) == '*'
+	}
+
+	count := 0
+	seen := make(map[[2]int]bool)
+	directions := [][2]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}}
+	for cell := range overlay {
+		for _, d := range directions {
+			n := [2]int{cell[0] + d[0], cell[1] + d[1]}
+			if seen[n] {
+				continue
+			}
+			seen[n] = true
+			if dead(n[0], n[1], true) && !dead(n[0], n[1], false) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// mostOpenNeighbors keeps the positions that would leave the most empty
+// cells next to the word, preserving their order
+func (c *Crossword) mostOpenNeighbors(word string, positions []Position) []Position {
+	var kept []Position
+	most := -1
+	for _, p := range positions {
+		open := c.openNeighbors(word, p.X, p.Y, p.Dir)
+		if open > most {
+			most = open
+			kept = kept[:0]
+		}
+		if open == most {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+// openNeighbors counts the distinct empty cells next to a candidate
+// placement that it would leave empty, its end blocks excluded
+func (c *Crossword) openNeighbors(word string, x, y int, dir Direction) int {
+	p := WordPlacement{X: x, Y: y, Dir: dir, Length: len(word)}
+
+	// The cells just past either end turn into blocks
+	seen := make(map[[2]int]bool)
+	for _, i := range []int{-1, len(word)} {
+		x1, y1 := p.cell(i)
+		seen[[2]int{x1, y1}] = true
+	}
+
+	count := 0
+	directions := [][2]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}}
+	for i := 0; i < len(word); i++ {
+		x1, y1 := p.cell(i)
+		for _, d := range directions {
+			n := [2]int{x1 + d[0], y1 + d[1]}
+			if seen[n] || p.covers(n[0], n[1]) || !c.isValidPosition(n[0], n[1]) {
+				continue
+			}
+			seen[n] = true
+
+			if c.board[n[0]][n[1]] == ' ' {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// nearEndpoints keeps the positions crossing an existing word within two
+// cells of one of its ends, preserving their order. All positions are
+// kept when none qualifies.
+func (c *Crossword) nearEndpoints(word string, positions []Position) []Position {
+	var kept []Position
+	for _, pos := range positions {
+		p := WordPlacement{X: pos.X, Y: pos.Y, Dir: pos.Dir, Length: len(word)}
+		for _, q := range c.CrossingPlacements(p) {
+			// Offset of the crossing along the existing word
+			i := p.X - q.X
+			if q.Dir == Horizontal {
+				i = p.Y - q.Y
+			}
+			if i <= 2 || q.Length-1-i <= 2 {
+				kept = append(kept, pos)
+				break
+			}
+		}
+	}
+
+	if len(kept) == 0 {
+		return positions
+	}
+	return kept
+}
+
+// crossingStarts returns the starts, in no particular order, at which the
+// word would run through a letter on the board with the same letter. Such
+// a word always reaches into an empty cell next to the crossed letter,
+// unless it is made of crossings alone, so the starts are found from the
+// frontier.
+func (c *Crossword) crossingStarts(word string, directions []Direction) []Position {
+	size := 2 * c.width * c.height
+	if len(c.marks) != size {
+		c.marks = make([]bool, size)
+	}
+
+	// The letters of the word, so most board letters are ruled out at once
+	var letters [256 / 64]uint64
+	for i := 0; i < len(word); i++ {
+		letters[word[i]/64] |= 1 << (word[i] % 64)
+	}
+
+	starts := c.starts[:0]
+	for f := range c.frontierIndex() {
+		for _, dir := range directions {
+			dx, dy := 1, 0
+			if dir == Horizontal {
+				dx, dy = 0, 1
+			}
+
+			for _, side := range []int{-1, 1} {
+				x, y := f[0]+side*dx, f[1]+side*dy
+				if !c.isValidPosition(x, y) || !isLetter(c.board[x][y]) {
+					continue
+				}
+
+				// A letter already inside a word running this way can't be crossed
+				if dir == Horizontal && c.hWords[x][y] > 0 || dir == Vertical && c.vWords[x][y] > 0 {
+					continue
+				}
+
+				// Words hold bytes, so wider letters are never matched
+				letter := c.board[x][y]
+				if letter > 0xff || letters[letter/64]&(1<<(letter%64)) == 0 {
+					continue
+				}
+				for i := 0; i < len(word); i++ {
+					j := strings.IndexByte(word[i:], byte(letter))
+					if j < 0 {
+						break
+					}
+					i += j
+
+					sx, sy := x-i*dx, y-i*dy
+					if sx < 0 || sy < 0 {
+						continue
+					}
+					if k := c.startIndex(sx, sy, dir); !c.marks[k] {
+						c.marks[k] = true
+						starts = append(starts, Position{X: sx, Y: sy, Dir: dir})
+					}
+				}
+			}
+		}
+	}
+
+	// Unmark only what was marked, keeping the call independent of the board size
+	for _, s := range starts {
+		c.marks[c.startIndex(s.X, s.Y, s.Dir)] = false
+	}
+
+	c.starts = starts
+	return starts
+}
+
+// startIndex returns the position of a start, or of a cell and direction,
+// in the fast scan marks and the cellOpen memo
+func (c *Crossword) startIndex(x, y int, dir Direction) int {
+	return (int(dir)*c.height+x)*c.width + y
+}
+
+// sortScanOrder sorts starts in the order the full scan visits their cells,
+// horizontal before vertical on the same cell
+func (c *Crossword) sortScanOrder(starts []Position) {
+	rank := func(p Position) int { return p.X*c.width + p.Y }
+	if c.opts.ScanOrder == ScanSpiral {
+		spiral := c.spiral().rank
+		rank = func(p Position) int { return spiral[p.X*c.width+p.Y] }
+	}
+
+	slices.SortFunc(starts, func(a, b Position) int {
+		if ra, rb := rank(a), rank(b); ra != rb {
+			return cmp.Compare(ra, rb)
+		}
+		return cmp.Compare(a.Dir, b.Dir)
+	})
+}
+
+// smallestBounds keeps the positions that grow the bounding box of the
+// placed words the least, preserving their order
+func (c *Crossword) smallestBounds(word string, positions []Position) []Position {
+	if len(c.placements) == 0 {
+		return positions
+	}
+
+	minX, minY, maxX, maxY := c.height, c.width, -1, -1
+	for _, p := range c.placements {
+		x1, y1 := p.cell(p.Length - 1)
+		minX, minY = min(minX, p.X), min(minY, p.Y)
+		maxX, maxY = max(maxX, x1), max(maxY, y1)
+	}
+
+	var kept []Position
+	smallest := -1
+	for _, pos := range positions {
+		p := WordPlacement{X: pos.X, Y: pos.Y, Dir: pos.Dir, Length: len(word)}
+		x1, y1 := p.cell(p.Length - 1)
+		area := (max(maxX, x1) - min(minX, p.X) + 1) * (max(maxY, y1) - min(minY, p.Y) + 1)
+		if smallest < 0 || area < smallest {
+			smallest = area
+			kept = kept[:0]
+		}
+		if area == smallest {
+			kept = append(kept, pos)
+		}
+	}
+	return kept
+}
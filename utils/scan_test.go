@@ -0,0 +1,313 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSpiralCells(t *testing.T) {
+	tests := []struct {
+		width, height int
+		first         [2]int
+	}{
+		{1, 1, [2]int{0, 0}},
+		{5, 5, [2]int{2, 2}},
+		{6, 4, [2]int{1, 2}},
+		{3, 9, [2]int{4, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%dx%d", tt.width, tt.height), func(t *testing.T) {
+			cells := spiralCells(tt.width, tt.height)
+			if len(cells) != tt.width*tt.height {
+				t.Fatalf("%d cells, want %d", len(cells), tt.width*tt.height)
+			}
+			if cells[0] != tt.first {
+				t.Errorf("spiral starts at %v, want %v", cells[0], tt.first)
+			}
+
+			seen := make(map[[2]int]bool)
+			for _, cell := range cells {
+				if cell[0] < 0 || cell[0] >= tt.height || cell[1] < 0 || cell[1] >= tt.width || seen[cell] {
+					t.Fatalf("cell %v outside the grid or repeated", cell)
+				}
+				seen[cell] = true
+			}
+		})
+	}
+}
+
+func TestScanSpiralClustersAtCenter(t *testing.T) {
+	// Mean distance of the first placed letters from the center of the grid
+	spread := func(order ScanOrder) float64 {
+		total, count := 0.0, 0
+		for seed := int64(1); seed <= 10; seed++ {
+			c := NewCrossword(15, 15)
+			words := ShuffleWords(testWords, rand.New(rand.NewSource(seed)))
+			c.GenerateWithOptions(words, GenerateOptions{ScanOrder: order, DeterministicTieBreak: true})
+
+			for _, p := range c.GetPlacements()[:3] {
+				for i := 0; i < p.Length; i++ {
+					x, y := p.cell(i)
+					total += math.Hypot(float64(x-7), float64(y-7))
+					count++
+				}
+			}
+		}
+		return total / float64(count)
+	}
+
+	rowMajor, spiral := spread(ScanRowMajor), spread(ScanSpiral)
+	if spiral >= rowMajor {
+		t.Errorf("spiral placements %.2f cells from the center, row-major %.2f", spiral, rowMajor)
+	}
+}
+
+func TestScanDoesNotAllocate(t *testing.T) {
+	tests := []struct {
+		name string
+		opts GenerateOptions
+	}{
+		{"row-major", GenerateOptions{}},
+		{"spiral", GenerateOptions{ScanOrder: ScanSpiral}},
+		{"fast", GenerateOptions{FastScan: true}},
+		{"fast spiral", GenerateOptions{FastScan: true, ScanOrder: ScanSpiral}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := crossingPuzzle()
+			c.opts = tt.opts
+			c.opts.DeterministicTieBreak = true
+
+			allocs := testing.AllocsPerRun(10, func() {
+				c.findBestPosition("SALE")
+			})
+			if allocs > 0 {
+				t.Errorf("%v allocations per scan", allocs)
+			}
+		})
+	}
+}
+
+func TestFewestDeadEnds(t *testing.T) {
+	// The empty cell (2,2) is walled in on three sides
+	c := NewCrossword(5, 5)
+	for _, cell := range [][2]int{{2, 1}, {1, 2}, {3, 2}} {
+		c.board[cell[0]][cell[1]] = '*'
+	}
+
+	tests := []struct {
+		name string
+		pos  Position
+		dead int
+	}{
+		{"closes the wall", Position{X: 0, Y: 3, Dir: Vertical}, 1},
+		{"one column over", Position{X: 0, Y: 4, Dir: Vertical}, 0},
+		{"across the top", Position{X: 0, Y: 0, Dir: Horizontal}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.newDeadEnds("CA", tt.pos.X, tt.pos.Y, tt.pos.Dir); got != tt.dead {
+				t.Errorf("newDeadEnds() = %d, want %d", got, tt.dead)
+			}
+		})
+	}
+
+	kept := c.fewestDeadEnds("CA", []Position{tests[0].pos, tests[1].pos, tests[2].pos})
+	if len(kept) != 2 || kept[0] != tests[1].pos || kept[1] != tests[2].pos {
+		t.Errorf("fewestDeadEnds() = %v, want the positions leaving no dead end", kept)
+	}
+}
+
+func TestAvoidDeadEndsDensity(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+	}{
+		{"small", 10},
+		{"medium", 12},
+		{"large", 15},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			density := func(avoid bool) float64 {
+				total := 0.0
+				for seed := int64(1); seed <= 30; seed++ {
+					rng := rand.New(rand.NewSource(seed))
+					c := NewCrossword(tt.size, tt.size)
+					c.GenerateWithOptions(testWords, GenerateOptions{Rand: rng.Intn, AvoidDeadEnds: avoid})
+					total += c.Report().Density
+				}
+				return total / 30
+			}
+
+			// Allow for the noise of 30 random grids
+			with, without := density(true), density(false)
+			if with < without-0.005 {
+				t.Errorf("average density %.4f with AvoidDeadEnds, %.4f without", with, without)
+			}
+		})
+	}
+}
+
+func TestPreferOpenSpace(t *testing.T) {
+	tests := []struct {
+		name string
+		seed int64
+		size int
+	}{
+		{"small", 1, 10},
+		{"medium", 2, 12},
+		{"large", 3, 15},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(tt.seed))
+			c := NewCrossword(tt.size, tt.size)
+			c.GenerateWithOptions(testWords[:4], GenerateOptions{Rand: rng.Intn})
+			c.opts = GenerateOptions{Rand: rng.Intn, PreferOpenSpace: true}
+
+			decided := 0
+			for _, word := range []string{"SOLE", "MARE", "LUNA", "STELLA", "CIELO", "NUVOLA", "PIOGGIA"} {
+				candidates, _ := c.scanPositions(word, c.openDirections(), false, nil)
+				most, fewest := -1, math.MaxInt
+				for _, p := range candidates {
+					open := c.openNeighbors(word, p.X, p.Y, p.Dir)
+					most, fewest = max(most, open), min(fewest, open)
+				}
+				if most != fewest {
+					decided++
+				}
+
+				best, ok := c.findBestPosition(word)
+				if !ok {
+					continue
+				}
+				if open := c.openNeighbors(word, best.X, best.Y, best.Dir); open != most {
+					t.Errorf("%s at %+v has %d open neighbors, the best candidate %d", word, best, open, most)
+				}
+			}
+			if decided == 0 {
+				t.Error("no word had candidates differing in open neighbors")
+			}
+		})
+	}
+}
+
+func TestEndpointBias(t *testing.T) {
+	// area is the bounding box area of the placed words
+	area := func(c *Crossword) int {
+		minX, minY, maxX, maxY := c.height, c.width, -1, -1
+		for _, p := range c.GetPlacements() {
+			x1, y1 := p.cell(p.Length - 1)
+			minX, minY = min(minX, p.X), min(minY, p.Y)
+			maxX, maxY = max(maxX, x1), max(maxY, y1)
+		}
+		return (maxX - minX + 1) * (maxY - minY + 1)
+	}
+
+	tests := []struct {
+		name string
+		size int
+	}{
+		{"medium", 15},
+		{"large", 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Total bounding box area and placed words over a fixed set of seeds
+			generate := func(bias bool) (int, int) {
+				total, words := 0, 0
+				for seed := int64(1); seed <= 20; seed++ {
+					c := NewCrossword(tt.size, tt.size)
+					rng := rand.New(rand.NewSource(seed))
+					c.GenerateWithOptions(testWords, GenerateOptions{Rand: rng.Intn, EndpointBias: bias})
+					total += area(c)
+					words += len(c.GetPlacements())
+				}
+				return total, words
+			}
+
+			plainArea, plainWords := generate(false)
+			biasedArea, biasedWords := generate(true)
+			if biasedArea >= plainArea {
+				t.Errorf("bounding boxes cover %d cells with EndpointBias, %d without", biasedArea, plainArea)
+			}
+			if biasedWords < plainWords {
+				t.Errorf("%d words placed with EndpointBias, %d without", biasedWords, plainWords)
+			}
+		})
+	}
+}
+
+func TestFastScanQuality(t *testing.T) {
+	words := dataWords(t)[:300]
+
+	tests := []struct {
+		name string
+		size int
+	}{
+		{"small", 15},
+		{"medium", 25},
+		{"large", 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Placed words and crossings over a fixed set of seeds
+			generate := func(fast bool) (int, int) {
+				placed, crossings := 0, 0
+				for seed := int64(1); seed <= 5; seed++ {
+					c := NewCrossword(tt.size, tt.size)
+					shuffled := ShuffleWords(words, rand.New(rand.NewSource(seed)))
+					c.GenerateWithOptions(shuffled, GenerateOptions{Rand: rand.New(rand.NewSource(seed)).Intn, FastScan: fast})
+
+					report := c.Report()
+					if fast && !report.Connected {
+						t.Errorf("seed %d: fast scan grid is not connected", seed)
+					}
+					placed += report.Words
+					crossings += report.Intersections
+				}
+				return placed, crossings
+			}
+
+			fullPlaced, fullCrossings := generate(false)
+			fastPlaced, fastCrossings := generate(true)
+			if float64(fastPlaced) < 0.75*float64(fullPlaced) {
+				t.Errorf("fast scan placed %d words, full scan %d", fastPlaced, fullPlaced)
+			}
+			if float64(fastCrossings) < 0.9*float64(fullCrossings) {
+				t.Errorf("fast scan made %d crossings, full scan %d", fastCrossings, fullCrossings)
+			}
+		})
+	}
+}
+
+// BenchmarkFastScan generates a large grid from the word list with the
+// full scan and with the fast scan, which only tries crossing starts
+func BenchmarkFastScan(b *testing.B) {
+	words := dataWords(b)[:300]
+
+	for _, size := range []int{25, 50} {
+		for _, fast := range []bool{false, true} {
+			name := fmt.Sprintf("%dx%d/full", size, size)
+			if fast {
+				name = fmt.Sprintf("%dx%d/fast", size, size)
+			}
+			b.Run(name, func(b *testing.B) {
+				c := NewCrossword(size, size)
+				for i := 0; i < b.N; i++ {
+					c.Reset()
+					c.GenerateWithOptions(words, GenerateOptions{Rand: rand.New(rand.NewSource(1)).Intn, FastScan: fast})
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"math/rand"
+	"strings"
+)
+
+// Scramble returns a copy of the board with every letter replaced through a
+// substitution derived from key, leaving blocks and empty cells untouched.
+// Only the Latin letters A-Z, in either case, are substituted. The board
+// holds the first letter of rebus cells, ScrambleRebus covers the rest.
+func (c *Crossword) Scramble(key int64) [][]rune {
+	forward, _ := substitution(key)
+	return substitute(c.board, forward)
+}
+
+// Unscramble reverses Scramble for the same key
+func Unscramble(scrambled [][]rune, key int64) [][]rune {
+	_, backward := substitution(key)
+	return substitute(scrambled, backward)
+}
+
+// ScrambleRebus returns the rebus cells, keyed by {row, col}, with their
+// letters replaced through the same substitution as Scramble
+func (c *Crossword) ScrambleRebus(key int64) map[[2]int]string {
+	forward, _ := substitution(key)
+	return substituteRebus(c.rebus, forward)
+}
+
+// UnscrambleRebus reverses ScrambleRebus for the same key
+func UnscrambleRebus(scrambled map[[2]int]string, key int64) map[[2]int]string {
+	_, backward := substitution(key)
+	return substituteRebus(scrambled, backward)
+}
+
+// substitution returns a permutation of the alphabet seeded by key and its
+// inverse
+func substitution(key int64) (forward, backward [26]int) {
+	perm := rand.New(rand.NewSource(key)).Perm(26)
+	for i, j := range perm {
+		forward[i] = j
+		backward[j] = i
+	}
+	return forward, backward
+}
+
+// substitute returns a copy of board with each Latin letter mapped through
+// perm, keeping its case
+func substitute(board [][]rune, perm [26]int) [][]rune {
+	out := make([][]rune, len(board))
+	for x, row := range board {
+		out[x] = make([]rune, len(row))
+		for y, cell := range row {
+			out[x][y] = substituteRune(cell, perm)
+		}
+	}
+	return out
+}
+
+// substituteRebus returns a copy of the rebus cells with each Latin letter
+// mapped through perm
+func substituteRebus(cells map[[2]int]string, perm [26]int) map[[2]int]string {
+	out := make(map[[2]int]string, len(cells))
+	for cell, letters := range cells {
+		out[cell] = strings.Map(func(r rune) rune { return substituteRune(r, perm) }, letters)
+	}
+	return out
+}
+
+// substituteRune maps a Latin letter through perm, keeping its case, and
+// returns any other rune unchanged
+func substituteRune(r rune, perm [26]int) rune {
+	switch {
+	case r >= 'A' && r <= 'Z':
+		return 'A' + rune(perm[r-'A'])
+	case r >= 'a' && r <= 'z':
+		return 'a' + rune(perm[r-'a'])
+	}
+	return r
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestScrambleRoundTrip(t *testing.T) {
+	generated := NewCrossword(12, 12)
+	generated.GenerateWithOptions(testWords, GenerateOptions{Rand: rand.New(rand.NewSource(2)).Intn})
+	lower, err := CrosswordFromGrid([]string{"gatto#", "a#....", "Città."})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		puzzle *Crossword
+		key    int64
+	}{
+		{"crossing", crossingPuzzle(), 1},
+		{"generated", generated, 42},
+		{"lowercase and accents", lower, -7},
+		{"empty", NewCrossword(3, 3), 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := tt.puzzle.GetBoard()
+			scrambled := tt.puzzle.Scramble(tt.key)
+
+			changed := false
+			for x := range board {
+				for y, cell := range board[x] {
+					got := scrambled[x][y]
+					latin := cell >= 'A' && cell <= 'Z' || cell >= 'a' && cell <= 'z'
+					if !latin && got != cell {
+						t.Errorf("cell (%d,%d) %q scrambled to %q", x, y, cell, got)
+					}
+					if latin && (got >= 'a') != (cell >= 'a') {
+						t.Errorf("cell (%d,%d) %q changed case to %q", x, y, cell, got)
+					}
+					changed = changed || got != cell
+				}
+			}
+			if !changed && len(tt.puzzle.GetPlacements()) > 0 {
+				t.Error("scrambling changed no letter")
+			}
+
+			unscrambled := Unscramble(scrambled, tt.key)
+			for x := range board {
+				if !slices.Equal(unscrambled[x], board[x]) {
+					t.Errorf("row %d = %q after the round trip, want %q", x, string(unscrambled[x]), string(board[x]))
+				}
+			}
+
+			if other := Unscramble(scrambled, tt.key+1); len(tt.puzzle.GetPlacements()) > 0 && slices.EqualFunc(other, board, slices.Equal) {
+				t.Error("another key unscrambles the board too")
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package utils
+
+import "sort"
+
+// GenerateSeries spreads the words over count puzzles of the given size.
+// Words are dealt round-robin by descending length so every puzzle gets a
+// similar mix, and words left out of their puzzle are offered to the
+// others. It returns the puzzles and the words that fit in none of them.
+func GenerateSeries(words []string, width, height, count int) ([]*Crossword, []string) {
+	if count <= 0 {
+		return nil, append([]string(nil), words...)
+	}
+
+	sorted := append([]string(nil), words...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i]) > len(sorted[j])
+	})
+
+	groups := make([][]string, count)
+	for i, word := range sorted {
+		groups[i%count] = append(groups[i%count], word)
+	}
+
+	puzzles := make([]*Crossword, count)
+	var leftover []string
+	for i, group := range groups {
+		puzzles[i] = NewCrossword(width, height)
+		result := puzzles[i].GenerateWithOptions(group, GenerateOptions{})
+		leftover = append(leftover, result.Unplaced...)
+	}
+
+	// Offer the leftovers to every puzzle in turn
+	for _, puzzle := range puzzles {
+		if len(leftover) == 0 {
+			break
+		}
+		leftover = puzzle.Fill(leftover).Unplaced
+	}
+
+	return puzzles, leftover
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGenerateSeries(t *testing.T) {
+	var vocabulary []string
+	for _, word := range dataWords(t) {
+		ascii := !slices.ContainsFunc([]rune(word), func(r rune) bool { return r < 'A' || r > 'Z' })
+		if ascii && len(word) >= 3 && len(word) <= 8 && !slices.Contains(vocabulary, word) {
+			vocabulary = append(vocabulary, word)
+		}
+		if len(vocabulary) == 60 {
+			break
+		}
+	}
+
+	tests := []struct {
+		name          string
+		words         []string
+		width, height int
+		count         int
+	}{
+		{"animals in two", testWords, 12, 12, 2},
+		{"animals in three", testWords, 10, 10, 3},
+		{"vocabulary in four", vocabulary, 15, 15, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			puzzles, leftover := GenerateSeries(tt.words, tt.width, tt.height, tt.count)
+			if len(puzzles) != tt.count {
+				t.Fatalf("got %d puzzles, want %d", len(puzzles), tt.count)
+			}
+
+			var placed []string
+			for _, puzzle := range puzzles {
+				for _, p := range puzzle.GetPlacements() {
+					if slices.Contains(placed, p.Word) {
+						t.Errorf("%q placed twice", p.Word)
+					}
+					placed = append(placed, p.Word)
+				}
+			}
+
+			if len(placed)+len(leftover) != len(tt.words) {
+				t.Errorf("%d placed and %d left over, want %d words in all", len(placed), len(leftover), len(tt.words))
+			}
+			if covered := float64(len(placed)) / float64(len(tt.words)); covered < 0.8 {
+				t.Errorf("series covers %.0f%% of the words, want at least 80%%", covered*100)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// SetTemplate marks the given cells as permanent blocks that no word can
+// cross. The template must match the board dimensions and must not cover
+// any placed letter.
+func (c *Crossword) SetTemplate(blocks [][]bool) error {
+	if len(blocks) != c.height {
+		return fmt.Errorf("template has %d rows, expected %d", len(blocks), c.height)
+	}
+	for x := range blocks {
+		if len(blocks[x]) != c.width {
+			return fmt.Errorf("template row %d has %d columns, expected %d", x, len(blocks[x]), c.width)
+		}
+		for y, block := range blocks[x] {
+			if block && (c.hWords[x][y] > 0 || c.vWords[x][y] > 0) {
+				return fmt.Errorf("template block at (%d,%d) covers a placed word", x, y)
+			}
+		}
+	}
+
+	c.template = make([][]bool, c.height)
+	for x := range blocks {
+		c.template[x] = make([]bool, c.width)
+		copy(c.template[x], blocks[x])
+		for y, block := range blocks[x] {
+			if block {
+				c.board[x][y] = '*'
+			}
+		}
+	}
+	c.frontier = nil
+
+	return nil
+}
+
+// isTemplateBlock checks if the cell is a permanent template block
+func (c *Crossword) isTemplateBlock(x, y int) bool {
+	return c.template != nil && c.template[x][y]
+}
+
+// RandomBlocks marks about ratio of the board's cells as blocks, chosen at
+// random among the cells without letters. The same seed gives the same
+// pattern. Meant as scaffolding for renderer tests.
+func (c *Crossword) RandomBlocks(ratio float64, seed int64) {
+	var free [][2]int
+	for x := range c.board {
+		for y, cell := range c.board[x] {
+			if !isLetter(cell) {
+				free = append(free, [2]int{x, y})
+			}
+		}
+	}
+
+	count := int(math.Round(ratio * float64(c.width*c.height)))
+	count = max(0, min(count, len(free)))
+
+	rng := rand.New(rand.NewSource(seed))
+	for _, i := range rng.Perm(len(free))[:count] {
+		c.board[free[i][0]][free[i][1]] = '*'
+	}
+	c.frontier = nil
+}
+
+// SetMask restricts the puzzle to the cells set in mask, for shaped grids.
+// Cells outside it are treated as off the board: no word touches them and
+// they are not drawn. The mask must match the board dimensions and keep
+// every placed letter.
+func (c *Crossword) SetMask(mask [][]bool) error {
+	if len(mask) != c.height {
+		return fmt.Errorf("mask has %d rows, expected %d", len(mask), c.height)
+	}
+	for x := range mask {
+		if len(mask[x]) != c.width {
+			return fmt.Errorf("mask row %d has %d columns, expected %d", x, len(mask[x]), c.width)
+		}
+		for y, usable := range mask[x] {
+			if !usable && c.board[x][y] != ' ' {
+				return fmt.Errorf("mask excludes the occupied cell (%d,%d)", x, y)
+			}
+		}
+	}
+
+	c.mask = make([][]bool, c.height)
+	for x := range mask {
+		c.mask[x] = append([]bool(nil), mask[x]...)
+	}
+	c.frontier = nil
+
+	return nil
+}
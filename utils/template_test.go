@@ -0,0 +1,191 @@
+package utils
+
+import (
+	"fmt"
+	"image/color"
+	"math/rand"
+	"testing"
+)
+
+// blockGrid returns a width by height template with the given cells set
+func blockGrid(width, height int, cells ...[2]int) [][]bool {
+	grid := make([][]bool, height)
+	for x := range grid {
+		grid[x] = make([]bool, width)
+	}
+	for _, cell := range cells {
+		grid[cell[0]][cell[1]] = true
+	}
+	return grid
+}
+
+func TestSetTemplateBlocksWords(t *testing.T) {
+	center := [2]int{4, 4}
+	for seed := int64(1); seed <= 5; seed++ {
+		t.Run(fmt.Sprintf("seed %d", seed), func(t *testing.T) {
+			c := NewCrossword(9, 9)
+			if err := c.SetTemplate(blockGrid(9, 9, center)); err != nil {
+				t.Fatalf("SetTemplate() error = %v", err)
+			}
+
+			rng := rand.New(rand.NewSource(seed))
+			c.GenerateWithOptions(testWords, GenerateOptions{Rand: rng.Intn})
+
+			if c.GetBoard()[4][4] != '*' {
+				t.Errorf("template block overwritten with %q", c.GetBoard()[4][4])
+			}
+			for _, p := range c.GetPlacements() {
+				if p.covers(center[0], center[1]) {
+					t.Errorf("%s crosses the template block", p.Word)
+				}
+			}
+		})
+	}
+}
+
+func TestSetTemplateInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		blocks [][]bool
+	}{
+		{"too few rows", blockGrid(6, 5)},
+		{"too few columns", blockGrid(5, 6)},
+		{"covers a letter", blockGrid(6, 6, [2]int{1, 2})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := crossingPuzzle()
+			if err := c.SetTemplate(tt.blocks); err == nil {
+				t.Error("SetTemplate() succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestRandomBlocks(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		ratio         float64
+		seed          int64
+	}{
+		{"none", 10, 10, 0, 1},
+		{"sparse", 15, 15, 0.15, 2},
+		{"quarter", 20, 10, 0.25, 3},
+		{"half", 9, 9, 0.5, 4},
+		{"all", 5, 5, 1, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCrossword(tt.width, tt.height)
+			c.RandomBlocks(tt.ratio, tt.seed)
+
+			blocks := 0
+			for x := range c.board {
+				for _, cell := range c.board[x] {
+					if cell == '*' {
+						blocks++
+					}
+				}
+			}
+			got := float64(blocks) / float64(tt.width*tt.height)
+			if diff := got - tt.ratio; diff > 0.02 || diff < -0.02 {
+				t.Errorf("block ratio = %.3f, want %.3f", got, tt.ratio)
+			}
+
+			again := NewCrossword(tt.width, tt.height)
+			again.RandomBlocks(tt.ratio, tt.seed)
+			if !c.Equal(again) {
+				t.Error("same seed gave a different pattern")
+			}
+		})
+	}
+}
+
+func TestSetMaskLShape(t *testing.T) {
+	// An L: the four left columns and the four bottom rows of a 10x10 grid
+	var cells [][2]int
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 10; y++ {
+			if y < 4 || x >= 6 {
+				cells = append(cells, [2]int{x, y})
+			}
+		}
+	}
+	mask := blockGrid(10, 10, cells...)
+
+	for seed := int64(1); seed <= 5; seed++ {
+		t.Run(fmt.Sprintf("seed %d", seed), func(t *testing.T) {
+			c := NewCrossword(10, 10)
+			if err := c.SetMask(mask); err != nil {
+				t.Fatalf("SetMask() error = %v", err)
+			}
+
+			rng := rand.New(rand.NewSource(seed))
+			c.GenerateWithOptions(testWords, GenerateOptions{Rand: rng.Intn})
+			if len(c.GetPlacements()) == 0 {
+				t.Fatal("no words placed")
+			}
+
+			for _, p := range c.GetPlacements() {
+				for i := 0; i < p.Length; i++ {
+					if x, y := p.cell(i); !mask[x][y] {
+						t.Errorf("%s covers (%d,%d), outside the mask", p.Word, x, y)
+					}
+				}
+			}
+			for x, row := range c.GetBoard() {
+				for y, cell := range row {
+					if !mask[x][y] && cell != ' ' {
+						t.Errorf("cell (%d,%d) outside the mask holds %q", x, y, cell)
+					}
+				}
+			}
+
+			// Cells outside the mask are left as background
+			config := DefaultConfig()
+			img, err := RenderPuzzleImage(c, config)
+			if err != nil {
+				t.Fatalf("RenderPuzzleImage() error = %v", err)
+			}
+			rect := cellRect(config, 2, 7)
+			if got := countColor(img, rect, color.White); got != rect.Dx()*rect.Dy() {
+				t.Errorf("%d of %d background pixels in the masked-out cell (2,7)", got, rect.Dx()*rect.Dy())
+			}
+		})
+	}
+}
+
+func TestSetMaskInvalid(t *testing.T) {
+	full := func(width, height int) [][]bool {
+		var cells [][2]int
+		for x := 0; x < height; x++ {
+			for y := 0; y < width; y++ {
+				cells = append(cells, [2]int{x, y})
+			}
+		}
+		return blockGrid(width, height, cells...)
+	}
+	excluding := full(6, 6)
+	excluding[1][2] = false
+
+	tests := []struct {
+		name string
+		mask [][]bool
+	}{
+		{"too few rows", full(6, 5)},
+		{"too few columns", full(5, 6)},
+		{"excludes a letter", excluding},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := crossingPuzzle()
+			if err := c.SetMask(tt.mask); err == nil {
+				t.Error("SetMask() succeeded, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ANSI escape sequences used by RenderTerminal
+const (
+	ansiReset  = "\x1b[0m"
+	ansiLetter = "\x1b[1;36m"
+	ansiBlock  = "\x1b[90m"
+	ansiDim    = "\x1b[2m"
+)
+
+// RenderTerminal returns the grid followed by the numbered word placements
+// as text. With color set, letters are highlighted, blocks drawn as filled
+// squares and numbers dimmed using ANSI escape codes.
+func RenderTerminal(puzzle *Crossword, color bool) string {
+	var sb strings.Builder
+
+	for _, row := range puzzle.GetBoard() {
+		for _, cell := range row {
+			switch {
+			case !color:
+				sb.WriteRune(cell)
+			case cell == '*':
+				sb.WriteString(ansiBlock + "█" + ansiReset)
+			case cell == ' ':
+				sb.WriteRune(' ')
+			default:
+				sb.WriteString(ansiLetter + string(cell) + ansiReset)
+			}
+			sb.WriteRune(' ')
+		}
+		sb.WriteRune('\n')
+	}
+
+	sb.WriteString("\nWord Placements:\n")
+	for _, placement := range puzzle.GetPlacements() {
+		direction := "Across"
+		if placement.Dir == Vertical {
+			direction = "Down"
+		}
+
+		number := fmt.Sprintf("%d", placement.Number)
+		if color {
+			number = ansiDim + number + ansiReset
+		}
+		fmt.Fprintf(&sb, "%s %s: (%d,%d) %s\n", number, placement.Word, placement.X, placement.Y, direction)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderTerminal(t *testing.T) {
+	plain := "" +
+		"  *         \n" +
+		"* C A S A * \n" +
+		"  A         \n" +
+		"  N         \n" +
+		"  E         \n" +
+		"  *         \n" +
+		"\n" +
+		"Word Placements:\n" +
+		"1 CASA: (1,1) Across\n" +
+		"1 CANE: (1,1) Down\n"
+
+	tests := []struct {
+		name     string
+		color    bool
+		contains []string
+	}{
+		{"plain", false, nil},
+		{"color", true, []string{
+			ansiLetter + "C" + ansiReset,
+			ansiBlock + "█" + ansiReset,
+			ansiDim + "1" + ansiReset + " CASA",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RenderTerminal(crossingPuzzle(), tt.color)
+
+			if !tt.color {
+				if got != plain {
+					t.Errorf("RenderTerminal() =\n%s\nwant\n%s", got, plain)
+				}
+				return
+			}
+
+			if !strings.Contains(got, "\x1b[") {
+				t.Fatal("colored output has no ANSI escape codes")
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("colored output lacks %q", want)
+				}
+			}
+			if strings.Contains(got, "*") {
+				t.Error("colored output still shows '*' blocks")
+			}
+		})
+	}
+}
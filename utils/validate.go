@@ -0,0 +1,39 @@
+package utils
+
+import "strings"
+
+// SubstringConflicts returns the placements lying inside a longer run of
+// letters in the same direction, as pairs of the placed word and the run
+func (c *Crossword) SubstringConflicts() [][2]string {
+	entries := c.Entries()
+
+	var conflicts [][2]string
+	for _, p := range c.placements {
+		for _, e := range entries {
+			if e.Dir == p.Dir && e.covers(p.X, p.Y) && e.Length > p.Length {
+				conflicts = append(conflicts, [2]string{p.Word, e.Word})
+				break
+			}
+		}
+	}
+	return conflicts
+}
+
+// PlacementsConsistent checks that every placed word still matches the
+// letters on the board at its coordinates, including rebus cells
+func (c *Crossword) PlacementsConsistent() bool {
+	for _, p := range c.placements {
+		var letters strings.Builder
+		for i := 0; i < p.Length; i++ {
+			x, y := p.cell(i)
+			if !c.isValidPosition(x, y) || !isLetter(c.board[x][y]) {
+				return false
+			}
+			letters.WriteString(c.cellText(x, y))
+		}
+		if letters.String() != p.Word {
+			return false
+		}
+	}
+	return true
+}
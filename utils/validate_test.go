@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSubstringConflicts(t *testing.T) {
+	// ROMA placed, then extended on the board into ROMANO
+	extended := NewCrossword(8, 3)
+	extended.putWord("ROMA", 1, 0, Horizontal)
+	copy(extended.board[1][4:], []rune("NO"))
+
+	tests := []struct {
+		name string
+		c    *Crossword
+		want [][2]string
+	}{
+		{"clean", crossingPuzzle(), nil},
+		{"extended", extended, [][2]string{{"ROMA", "ROMANO"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.SubstringConflicts(); !slices.Equal(got, tt.want) {
+				t.Errorf("SubstringConflicts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubstringPlacementPrevented(t *testing.T) {
+	c := NewCrossword(8, 8)
+	c.putWord("ROMANO", 2, 1, Horizontal)
+
+	tests := []struct {
+		name string
+		word string
+		x, y int
+		dir  Direction
+	}{
+		{"prefix", "ROMA", 2, 1, Horizontal},
+		{"suffix", "MANO", 2, 3, Horizontal},
+		{"middle", "OMAN", 2, 2, Horizontal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if n := c.canBePlaced(tt.word, tt.x, tt.y, tt.dir); n >= 0 {
+				t.Errorf("canBePlaced(%s) = %d, want it refused", tt.word, n)
+			}
+		})
+	}
+
+	c.GeneratePuzzle([]string{"ROMA", "MANO"})
+	if conflicts := c.SubstringConflicts(); len(conflicts) > 0 {
+		t.Errorf("generation left conflicts %v", conflicts)
+	}
+}
+
+func TestPlacementsConsistent(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(c *Crossword)
+		want    bool
+	}{
+		{"untouched", func(c *Crossword) {}, true},
+		{"changed letter", func(c *Crossword) { c.board[1][3] = 'X' }, false},
+		{"changed crossing", func(c *Crossword) { c.board[1][1] = 'K' }, false},
+		{"cleared letter", func(c *Crossword) { c.board[4][1] = ' ' }, false},
+		{"block over letter", func(c *Crossword) { c.board[2][1] = '*' }, false},
+		{"moved placement", func(c *Crossword) { c.placements[0].Y++ }, false},
+		{"letter outside words", func(c *Crossword) { c.board[4][4] = 'Z' }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := crossingPuzzle()
+			tt.corrupt(c)
+			if got := c.PlacementsConsistent(); got != tt.want {
+				t.Errorf("PlacementsConsistent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// NearDuplicates returns the pairs of words within maxDistance edits of each other
+func NearDuplicates(words []string, maxDistance int) [][2]string {
+	var pairs [][2]string
+	for i := 0; i < len(words); i++ {
+		for j := i + 1; j < len(words); j++ {
+			if levenshtein(words[i], words[j]) <= maxDistance {
+				pairs = append(pairs, [2]string{words[i], words[j]})
+			}
+		}
+	}
+	return pairs
+}
+
+// levenshtein computes the edit distance between two words
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	// Keep only the previous row of the distance matrix
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// ShuffleWords returns a shuffled copy of the words using the given source
+func ShuffleWords(words []string, rng *rand.Rand) []string {
+	shuffled := append([]string(nil), words...)
+	rng.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	return shuffled
+}
+
+// filterBanned splits words into those allowed and those matching the
+// banned list, ignoring case and surrounding spaces
+func filterBanned(words, banned []string) (allowed, skipped []string) {
+	if len(banned) == 0 {
+		return words, nil
+	}
+
+	normalized := make(map[string]bool, len(banned))
+	for _, b := range banned {
+		normalized[strings.ToLower(strings.TrimSpace(b))] = true
+	}
+
+	for _, word := range words {
+		if normalized[strings.ToLower(strings.TrimSpace(word))] {
+			skipped = append(skipped, word)
+		} else {
+			allowed = append(allowed, word)
+		}
+	}
+	return allowed, skipped
+}
+
+// uniqueWords appends to dst the words not in placed, dropping repeats so
+// each is kept once, at its first position. seen is cleared and used as
+// scratch space.
+func uniqueWords(dst, words []string, placed, seen map[string]bool) []string {
+	clear(seen)
+	for _, word := range words {
+		if !seen[word] && !placed[word] {
+			seen[word] = true
+			dst = append(dst, word)
+		}
+	}
+	return dst
+}
+
+// orderWords sorts the words in place into the order they should be attempted
+func orderWords(words []string, opts GenerateOptions) {
+	if opts.Order != OrderHardestFirst && !opts.PreferLong {
+		return
+	}
+
+	slices.SortStableFunc(words, func(a, b string) int {
+		if len(a) != len(b) {
+			return cmp.Compare(len(b), len(a))
+		}
+		return cmp.Compare(opts.Frequency[a], opts.Frequency[b])
+	})
+}
@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestNearDuplicates(t *testing.T) {
+	tests := []struct {
+		name        string
+		words       []string
+		maxDistance int
+		want        [][2]string
+	}{
+		{"one substitution", []string{"CASA", "CARA", "PORTA"}, 1, [][2]string{{"CASA", "CARA"}}},
+		{"one insertion", []string{"CASA", "CASE", "CASSA"}, 1, [][2]string{{"CASA", "CASE"}, {"CASA", "CASSA"}}},
+		{"too far", []string{"CASA", "COSE"}, 1, nil},
+		{"wider distance", []string{"CASA", "COSE"}, 2, [][2]string{{"CASA", "COSE"}}},
+		{"accented", []string{"CITTÀ", "CITTA"}, 1, [][2]string{{"CITTÀ", "CITTA"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NearDuplicates(tt.words, tt.maxDistance)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("NearDuplicates(%v, %d) = %v, want %v", tt.words, tt.maxDistance, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShuffleWords(t *testing.T) {
+	tests := []struct {
+		name  string
+		words []string
+	}{
+		{"empty", nil},
+		{"one", []string{"GATTO"}},
+		{"animals", testWords},
+		{"repeats", []string{"CANE", "CANE", "LUPO", "ORSO"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := slices.Clone(tt.words)
+			got := ShuffleWords(input, rand.New(rand.NewSource(1)))
+
+			if !slices.Equal(input, tt.words) {
+				t.Errorf("input changed to %v", input)
+			}
+
+			sorted, want := slices.Clone(got), slices.Clone(tt.words)
+			slices.Sort(sorted)
+			slices.Sort(want)
+			if !slices.Equal(sorted, want) {
+				t.Errorf("ShuffleWords() = %v, not a permutation of %v", got, tt.words)
+			}
+
+			if again := ShuffleWords(input, rand.New(rand.NewSource(1))); !slices.Equal(again, got) {
+				t.Errorf("same seed gave %v, then %v", got, again)
+			}
+		})
+	}
+
+	// A fixed seed gives a fixed order
+	got := ShuffleWords([]string{"A", "B", "C", "D", "E"}, rand.New(rand.NewSource(42)))
+	if want := []string{"C", "D", "E", "A", "B"}; !slices.Equal(got, want) {
+		t.Errorf("seed 42 gave %v, want %v", got, want)
+	}
+}
+
+func TestOrderWords(t *testing.T) {
+	tests := []struct {
+		name  string
+		words []string
+		opts  GenerateOptions
+		want  []string
+	}{
+		{"as given", []string{"ORSO", "PAPPAGALLO", "CANE"}, GenerateOptions{}, []string{"ORSO", "PAPPAGALLO", "CANE"}},
+		{"longest first", []string{"ORSO", "PAPPAGALLO", "GATTO"}, GenerateOptions{Order: OrderHardestFirst}, []string{"PAPPAGALLO", "GATTO", "ORSO"}},
+		{"rarest first on ties", []string{"CANE", "LUPO", "ORSO"}, GenerateOptions{
+			Order:     OrderHardestFirst,
+			Frequency: map[string]int{"CANE": 90, "LUPO": 10, "ORSO": 40},
+		}, []string{"LUPO", "ORSO", "CANE"}},
+		{"unknown words count as rarest", []string{"CANE", "LUPO"}, GenerateOptions{
+			Order:     OrderHardestFirst,
+			Frequency: map[string]int{"CANE": 90},
+		}, []string{"LUPO", "CANE"}},
+		{"stable without frequencies", []string{"TOPO", "CANE", "LUPO"}, GenerateOptions{Order: OrderHardestFirst}, []string{"TOPO", "CANE", "LUPO"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			words := slices.Clone(tt.words)
+			orderWords(words, tt.opts)
+			if !slices.Equal(words, tt.want) {
+				t.Errorf("orderWords() = %v, want %v", words, tt.want)
+			}
+		})
+	}
+}